@@ -2,6 +2,7 @@ package goauthsdk
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -25,6 +26,9 @@ type APIError struct {
 
 	// Title RFC7807 错误标题
 	Title string `json:"title,omitempty"`
+
+	// WWWAuthenticate 响应的 WWW-Authenticate 头部，可通过 ParseStepUpChallenge 解析 RFC 9470 质询
+	WWWAuthenticate string `json:"-"`
 }
 
 // Error 实现 error 接口
@@ -46,9 +50,23 @@ func (e *APIError) Error() string {
 	return code
 }
 
+// Is 支持 errors.Is 按 OAuth 错误码匹配哨兵错误，例如 ErrAccessDenied、ErrAuthorizationPending
+func (e *APIError) Is(target error) bool {
+	sentinel, ok := oauthErrorSentinels[e.Code]
+	return ok && sentinel == target
+}
+
 // decodeAPIError 从 HTTP 响应解析统一的 APIError
-// 优先按 RFC7807 problemDetails（内部类型）解码，其次尝试 {code, message}，最后兜底生成基于 HTTP status 的错误
+// 优先按 RFC7807 problemDetails（内部类型）解码，其次尝试 {code, message}、RFC 6749 {error, error_description}，
+// 最后兜底生成基于 HTTP status 的错误
 func decodeAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := decodeAPIErrorBody(resp, body)
+	apiErr.WWWAuthenticate = resp.Header.Get("WWW-Authenticate")
+	return apiErr
+}
+
+// decodeAPIErrorBody 按响应体格式解析 APIError
+func decodeAPIErrorBody(resp *http.Response, body []byte) *APIError {
 	// 尝试解析 RFC7807 problemDetails（内部类型）
 	var pd problemDetails
 	if err := json.Unmarshal(body, &pd); err == nil && (pd.Code != "" || pd.Title != "" || pd.Detail != "") {
@@ -78,6 +96,16 @@ func decodeAPIError(resp *http.Response, body []byte) *APIError {
 		}
 	}
 
+	// 尝试解析 RFC 6749 {error, error_description} 格式
+	var oauthErr oauthErrorResponse
+	if err := json.Unmarshal(body, &oauthErr); err == nil && oauthErr.Error != "" {
+		return &APIError{
+			Status: resp.StatusCode,
+			Code:   oauthErr.Error,
+			Detail: oauthErr.ErrorDescription,
+		}
+	}
+
 	// 兜底：基于 HTTP 状态码生成错误
 	return &APIError{
 		Status: resp.StatusCode,
@@ -94,3 +122,82 @@ func newBusinessError(httpStatus int, bizCode int, message string) *APIError {
 		Detail: message,
 	}
 }
+
+var (
+	// ErrStateMismatch 表示回调中的 state 与发起授权时不一致（可能是 CSRF 攻击）
+	ErrStateMismatch = errors.New("state mismatch")
+
+	// ErrIssuerMismatch 表示回调中的 iss 与配置的授权服务器标识不一致（RFC 9207）
+	ErrIssuerMismatch = errors.New("issuer mismatch")
+
+	// ErrAccessDenied 表示用户拒绝授权（error=access_denied）
+	ErrAccessDenied = errors.New("access_denied")
+
+	// ErrConsentRequired 表示需要用户同意但请求禁止交互（error=consent_required）
+	ErrConsentRequired = errors.New("consent_required")
+
+	// ErrLoginRequired 表示需要用户登录但请求禁止交互（error=login_required）
+	ErrLoginRequired = errors.New("login_required")
+
+	// ErrInteractionRequired 表示需要用户交互但请求禁止交互（error=interaction_required）
+	ErrInteractionRequired = errors.New("interaction_required")
+
+	// ErrAuthorizationPending 表示设备授权尚未完成，需继续轮询（error=authorization_pending，RFC 8628）
+	ErrAuthorizationPending = errors.New("authorization_pending")
+
+	// ErrSlowDown 表示设备授权轮询过于频繁，需增大轮询间隔（error=slow_down，RFC 8628）
+	ErrSlowDown = errors.New("slow_down")
+
+	// ErrDeviceCodeExpired 表示设备码已过期，需重新发起设备授权（error=expired_token，RFC 8628）
+	ErrDeviceCodeExpired = errors.New("expired_token")
+
+	// ErrUseDPoPNonce 表示服务端要求 DPoP 证明携带 nonce，且自动重试后仍未通过（error=use_dpop_nonce，RFC 9449）
+	ErrUseDPoPNonce = errors.New("use_dpop_nonce")
+
+	// ErrInsufficientUserAuthentication 表示用户认证等级或时效不满足资源要求（error=insufficient_user_authentication，RFC 9470）
+	ErrInsufficientUserAuthentication = errors.New("insufficient_user_authentication")
+)
+
+// oauthErrorSentinels 将 OAuth 错误码映射到对应的哨兵错误，供 errors.Is 匹配
+var oauthErrorSentinels = map[string]error{
+	"access_denied":         ErrAccessDenied,
+	"consent_required":      ErrConsentRequired,
+	"login_required":        ErrLoginRequired,
+	"interaction_required":  ErrInteractionRequired,
+	"authorization_pending": ErrAuthorizationPending,
+	"slow_down":             ErrSlowDown,
+	"expired_token":         ErrDeviceCodeExpired,
+	"use_dpop_nonce":        ErrUseDPoPNonce,
+
+	"insufficient_user_authentication": ErrInsufficientUserAuthentication,
+}
+
+// AuthorizationError 是授权回调中返回的错误（RFC 6749 4.1.2.1）
+// 可通过 errors.Is(err, ErrAccessDenied) 等判断具体错误，或通过 errors.As 获取完整信息
+type AuthorizationError struct {
+	// Code 错误码（error 参数），例如 access_denied、invalid_scope
+	Code string `json:"error"`
+
+	// Description 错误描述（error_description 参数）
+	Description string `json:"error_description,omitempty"`
+
+	// URI 错误说明页面地址（error_uri 参数）
+	URI string `json:"error_uri,omitempty"`
+
+	// State 回传的 state
+	State string `json:"state,omitempty"`
+}
+
+// Error 实现 error 接口
+func (e *AuthorizationError) Error() string {
+	if e.Description != "" {
+		return fmt.Sprintf("authorization failed: %s: %s", e.Code, e.Description)
+	}
+	return fmt.Sprintf("authorization failed: %s", e.Code)
+}
+
+// Is 支持 errors.Is 与 ErrAccessDenied、ErrConsentRequired 等哨兵错误匹配
+func (e *AuthorizationError) Is(target error) bool {
+	sentinel, ok := oauthErrorSentinels[e.Code]
+	return ok && sentinel == target
+}
@@ -17,3 +17,10 @@ type problemDetails struct {
 	Code   string `json:"code,omitempty"`  // 业务错误码（如 INVALID_TOKEN、INSUFFICIENT_SCOPE）
 	Detail string `json:"detail"`          // 错误详情描述
 }
+
+// oauthErrorResponse 是 RFC 6749 5.2 风格的错误响应结构（内部使用）
+// 例如设备授权轮询时返回的 { "error": "authorization_pending", "error_description": "..." }
+type oauthErrorResponse struct {
+	Error            string `json:"error"`                       // 错误码
+	ErrorDescription string `json:"error_description,omitempty"` // 错误描述
+}
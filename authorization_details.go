@@ -0,0 +1,207 @@
+package goauthsdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// AuthorizationDetail 是单条授权详情（RAR，RFC 9396 2）
+// 公共字段对应规范定义的通用成员，类型特定的字段保存在 Extra 中；
+// 通过 RegisterAuthorizationDetailType 注册类型后，可使用 Decode 解码为自定义结构体
+type AuthorizationDetail struct {
+	Type       string   `json:"type"`                 // 授权详情类型，例如 payment_initiation
+	Locations  []string `json:"locations,omitempty"`  // 资源所在位置
+	Actions    []string `json:"actions,omitempty"`    // 允许的操作
+	DataTypes  []string `json:"datatypes,omitempty"`  // 允许访问的数据类型
+	Identifier string   `json:"identifier,omitempty"` // 具体资源标识
+	Privileges []string `json:"privileges,omitempty"` // 权限等级
+
+	// Extra 类型特定的其他字段，例如 instructedAmount、creditorAccount
+	Extra map[string]any `json:"-"`
+}
+
+// AuthorizationDetails 是授权详情列表，对应 authorization_details 参数
+type AuthorizationDetails []AuthorizationDetail
+
+// authorizationDetailFields 是 AuthorizationDetail 的公共字段（不含 Extra），用于避免 JSON 编解码递归
+type authorizationDetailFields struct {
+	Type       string   `json:"type"`
+	Locations  []string `json:"locations,omitempty"`
+	Actions    []string `json:"actions,omitempty"`
+	DataTypes  []string `json:"datatypes,omitempty"`
+	Identifier string   `json:"identifier,omitempty"`
+	Privileges []string `json:"privileges,omitempty"`
+}
+
+// MarshalJSON 将公共字段与 Extra 合并为一个 JSON 对象，公共字段优先
+func (d AuthorizationDetail) MarshalJSON() ([]byte, error) {
+	common, err := json.Marshal(authorizationDetailFields{
+		Type:       d.Type,
+		Locations:  d.Locations,
+		Actions:    d.Actions,
+		DataTypes:  d.DataTypes,
+		Identifier: d.Identifier,
+		Privileges: d.Privileges,
+	})
+	if err != nil || len(d.Extra) == 0 {
+		return common, err
+	}
+
+	merged := make(map[string]any, len(d.Extra)+1)
+	for name, value := range d.Extra {
+		merged[name] = value
+	}
+	if err := json.Unmarshal(common, &merged); err != nil {
+		return nil, err
+	}
+	return json.Marshal(merged)
+}
+
+// UnmarshalJSON 解码公共字段，其余字段放入 Extra
+func (d *AuthorizationDetail) UnmarshalJSON(data []byte) error {
+	var common authorizationDetailFields
+	if err := json.Unmarshal(data, &common); err != nil {
+		return err
+	}
+	var extra map[string]any
+	if err := json.Unmarshal(data, &extra); err != nil {
+		return err
+	}
+	for _, name := range []string{"type", "locations", "actions", "datatypes", "identifier", "privileges"} {
+		delete(extra, name)
+	}
+	if len(extra) == 0 {
+		extra = nil
+	}
+
+	*d = AuthorizationDetail{
+		Type:       common.Type,
+		Locations:  common.Locations,
+		Actions:    common.Actions,
+		DataTypes:  common.DataTypes,
+		Identifier: common.Identifier,
+		Privileges: common.Privileges,
+		Extra:      extra,
+	}
+	return nil
+}
+
+// Decode 将授权详情解码为通过 RegisterAuthorizationDetailType 注册的类型
+// 返回值为注册时 newValue 返回的指针；类型未注册时返回错误
+//
+// 示例用法:
+//
+//	for _, detail := range token.AuthorizationDetails {
+//	    v, err := detail.Decode()
+//	    if err != nil {
+//	        continue
+//	    }
+//	    if payment, ok := v.(*PaymentInitiation); ok {
+//	        fmt.Println(payment.InstructedAmount.Amount)
+//	    }
+//	}
+func (d AuthorizationDetail) Decode() (any, error) {
+	authorizationDetailTypesMu.RLock()
+	newValue, ok := authorizationDetailTypes[d.Type]
+	authorizationDetailTypesMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("authorization detail type is not registered: %s", d.Type)
+	}
+
+	v := newValue()
+	if err := d.DecodeInto(v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// DecodeInto 将授权详情解码到 v（指向结构体的指针），无需注册类型
+func (d AuthorizationDetail) DecodeInto(v any) error {
+	data, err := json.Marshal(d)
+	if err != nil {
+		return fmt.Errorf("encode authorization detail: %w", err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("decode authorization detail %s: %w", d.Type, err)
+	}
+	return nil
+}
+
+// OfType 返回指定类型的授权详情
+func (d AuthorizationDetails) OfType(typ string) AuthorizationDetails {
+	var matched AuthorizationDetails
+	for _, detail := range d {
+		if detail.Type == typ {
+			matched = append(matched, detail)
+		}
+	}
+	return matched
+}
+
+var (
+	authorizationDetailTypesMu sync.RWMutex
+	authorizationDetailTypes   = make(map[string]func() any)
+)
+
+// RegisterAuthorizationDetailType 注册自定义授权详情类型，用于 AuthorizationDetail.Decode 的类型化解码
+// 通常在 init 中调用；重复注册同一类型时后注册的生效
+//
+// 参数:
+//   - typ: 授权详情的 type 取值
+//   - newValue: 返回新的目标值（指向结构体的指针）
+//
+// 示例用法:
+//
+//	type PaymentInitiation struct {
+//	    Type             string `json:"type"`
+//	    InstructedAmount struct {
+//	        Currency string `json:"currency"`
+//	        Amount   string `json:"amount"`
+//	    } `json:"instructedAmount"`
+//	    CreditorAccount struct {
+//	        IBAN string `json:"iban"`
+//	    } `json:"creditorAccount"`
+//	}
+//
+//	func init() {
+//	    goauthsdk.RegisterAuthorizationDetailType("payment_initiation", func() any { return &PaymentInitiation{} })
+//	}
+func RegisterAuthorizationDetailType(typ string, newValue func() any) {
+	authorizationDetailTypesMu.Lock()
+	defer authorizationDetailTypesMu.Unlock()
+	authorizationDetailTypes[typ] = newValue
+}
+
+// encodeAuthorizationDetails 将授权详情编码为 authorization_details 参数值（JSON 数组）
+// details 的元素可以是 AuthorizationDetail 或任意可序列化为 JSON 对象的自定义结构体，每个元素必须包含非空 type
+func encodeAuthorizationDetails(details []any) (string, error) {
+	if len(details) == 0 {
+		return "", fmt.Errorf("authorization_details must not be empty")
+	}
+
+	encoded := make([]json.RawMessage, 0, len(details))
+	for _, detail := range details {
+		data, err := json.Marshal(detail)
+		if err != nil {
+			return "", fmt.Errorf("encode authorization detail: %w", err)
+		}
+
+		var typed struct {
+			Type string `json:"type"`
+		}
+		if err := json.Unmarshal(data, &typed); err != nil {
+			return "", fmt.Errorf("authorization detail must be a json object: %w", err)
+		}
+		if typed.Type == "" {
+			return "", fmt.Errorf("authorization detail type is required")
+		}
+		encoded = append(encoded, data)
+	}
+
+	data, err := json.Marshal(encoded)
+	if err != nil {
+		return "", fmt.Errorf("encode authorization_details: %w", err)
+	}
+	return string(data), nil
+}
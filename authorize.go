@@ -12,6 +12,7 @@ import (
 // 参数:
 //   - state: 可选的状态参数，用于防止 CSRF 攻击
 //   - scope: 可选的权限范围，多个 scope 用空格分隔
+//   - opts: 可选的扩展参数，例如 WithPrompt、WithLoginHint、WithMaxAge、WithRedirectURI 等
 //
 // 示例用法:
 //
@@ -21,7 +22,14 @@ import (
 //	}
 //	// 将用户浏览器重定向到 authURL
 //	http.Redirect(w, r, authURL, http.StatusFound)
-func (c *Client) BuildAuthorizationURL(state, scope string) (string, error) {
+//
+//	// 要求重新登录并预填账号
+//	authURL, err = client.BuildAuthorizationURL("random-state-string", "read",
+//	    goauthsdk.WithPrompt("login"),
+//	    goauthsdk.WithLoginHint("alice@example.com"),
+//	    goauthsdk.WithMaxAge(5*time.Minute),
+//	)
+func (c *Client) BuildAuthorizationURL(state, scope string, opts ...AuthorizationOption) (string, error) {
 	// 构造前端授权确认页地址
 	u, err := url.Parse(c.cfg.FrontendBaseURL + "/oauth/authorize")
 	if err != nil {
@@ -29,10 +37,37 @@ func (c *Client) BuildAuthorizationURL(state, scope string) (string, error) {
 	}
 
 	// 构建 query 参数
-	q := u.Query()
+	q, err := c.buildAuthorizationQuery(state, scope, opts)
+	if err != nil {
+		return "", err
+	}
+
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// buildAuthorizationQuery 构建授权请求的完整参数集合
+func (c *Client) buildAuthorizationQuery(state, scope string, opts []AuthorizationOption) (url.Values, error) {
+	params := &authorizationParams{values: url.Values{}}
+	for _, opt := range opts {
+		opt(params)
+	}
+	if params.err != nil {
+		return nil, params.err
+	}
+
+	redirectURI := c.cfg.RedirectURI
+	if params.redirectURI != "" {
+		if err := c.validateRedirectURI(params.redirectURI); err != nil {
+			return nil, err
+		}
+		redirectURI = params.redirectURI
+	}
+
+	q := params.values
 	q.Set("response_type", "code")
 	q.Set("client_id", c.cfg.ClientID)
-	q.Set("redirect_uri", c.cfg.RedirectURI)
+	q.Set("redirect_uri", redirectURI)
 
 	if scope != "" {
 		q.Set("scope", scope)
@@ -42,6 +77,14 @@ func (c *Client) BuildAuthorizationURL(state, scope string) (string, error) {
 		q.Set("state", state)
 	}
 
-	u.RawQuery = q.Encode()
-	return u.String(), nil
+	// 使用请求对象时，全部参数签名后放入 request，外层只保留 client_id（RFC 9101 5）
+	if params.signedRequest {
+		requestObject, err := c.signRequestObject(q)
+		if err != nil {
+			return nil, err
+		}
+		return url.Values{"client_id": {c.cfg.ClientID}, "request": {requestObject}}, nil
+	}
+
+	return q, nil
 }
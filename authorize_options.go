@@ -0,0 +1,184 @@
+package goauthsdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// reservedAuthorizationParams 是由 SDK 维护、不允许通过 WithAuthorizationParam 覆盖的参数
+var reservedAuthorizationParams = map[string]bool{
+	"response_type": true,
+	"client_id":     true,
+	"redirect_uri":  true,
+	"state":         true,
+	"scope":         true,
+	"request":       true,
+	"request_uri":   true,
+
+	"code_challenge":        true,
+	"code_challenge_method": true,
+}
+
+// AuthorizationOption 用于设置授权请求的扩展参数
+type AuthorizationOption func(*authorizationParams)
+
+// authorizationParams 是授权请求的扩展参数集合
+type authorizationParams struct {
+	redirectURI   string
+	values        url.Values
+	signedRequest bool
+	err           error
+}
+
+// WithPrompt 设置 prompt 参数（OIDC），多个值以空格拼接
+// 常用值：none、login、consent、select_account
+func WithPrompt(prompt ...string) AuthorizationOption {
+	return func(p *authorizationParams) {
+		p.values.Set("prompt", strings.Join(prompt, " "))
+	}
+}
+
+// WithLoginHint 设置 login_hint 参数，提示授权服务器预填的登录账号
+func WithLoginHint(hint string) AuthorizationOption {
+	return func(p *authorizationParams) {
+		p.values.Set("login_hint", hint)
+	}
+}
+
+// WithMaxAge 设置 max_age 参数：用户最近一次认证距今超过该时长时要求重新认证
+// 时长按秒取整；传 0 表示要求立即重新认证
+func WithMaxAge(d time.Duration) AuthorizationOption {
+	return func(p *authorizationParams) {
+		if d < 0 {
+			p.err = fmt.Errorf("max_age must not be negative")
+			return
+		}
+		p.values.Set("max_age", strconv.FormatInt(int64(d/time.Second), 10))
+	}
+}
+
+// WithUILocales 设置 ui_locales 参数（BCP 47 语言标签，按偏好排序），例如 "zh-CN"、"en"
+func WithUILocales(locales ...string) AuthorizationOption {
+	return func(p *authorizationParams) {
+		p.values.Set("ui_locales", strings.Join(locales, " "))
+	}
+}
+
+// WithACRValues 设置 acr_values 参数（期望的认证上下文等级，按偏好排序）
+func WithACRValues(acrValues ...string) AuthorizationOption {
+	return func(p *authorizationParams) {
+		p.values.Set("acr_values", strings.Join(acrValues, " "))
+	}
+}
+
+// WithClaims 设置 claims 参数（OIDC Core 5.5）
+// claims 可以是 JSON 字符串、[]byte 或任意可被 json.Marshal 序列化的值
+func WithClaims(claims any) AuthorizationOption {
+	return func(p *authorizationParams) {
+		switch v := claims.(type) {
+		case string:
+			p.values.Set("claims", v)
+		case []byte:
+			p.values.Set("claims", string(v))
+		default:
+			data, err := json.Marshal(v)
+			if err != nil {
+				p.err = fmt.Errorf("encode claims: %w", err)
+				return
+			}
+			p.values.Set("claims", string(data))
+		}
+	}
+}
+
+// WithResponseMode 设置 response_mode 参数，例如 "query"、"fragment"、"form_post"
+// 使用 form_post 时，回调为 POST 请求，可通过 ParseAuthorizationCallback 解析
+func WithResponseMode(mode string) AuthorizationOption {
+	return func(p *authorizationParams) {
+		p.values.Set("response_mode", mode)
+	}
+}
+
+// WithRedirectURI 覆盖本次授权请求的 redirect_uri
+// 取值必须为初始化时的 redirectURI 或通过 WithAllowedRedirectURIs 配置的地址之一；
+// 使用覆盖地址时，ExchangeToken 需通过 WithTokenRedirectURI 传入相同地址
+func WithRedirectURI(redirectURI string) AuthorizationOption {
+	return func(p *authorizationParams) {
+		p.redirectURI = redirectURI
+	}
+}
+
+// WithResource 设置 resource 参数（RFC 8707），声明令牌将要访问的资源服务器，可传多个
+// 每个资源必须是不含 fragment 的绝对 URI，例如 https://api.example.com/orders
+func WithResource(resources ...string) AuthorizationOption {
+	return func(p *authorizationParams) {
+		for _, resource := range resources {
+			if err := validateResourceIndicator(resource); err != nil {
+				p.err = err
+				return
+			}
+			p.values.Add("resource", resource)
+		}
+	}
+}
+
+// WithAuthorizationDetails 设置 authorization_details 参数（RAR，RFC 9396），用于细粒度授权
+// details 的元素可以是 AuthorizationDetail 或任意可序列化为 JSON 对象的自定义结构体，每个元素必须包含非空 type
+func WithAuthorizationDetails(details ...any) AuthorizationOption {
+	return func(p *authorizationParams) {
+		encoded, err := encodeAuthorizationDetails(details)
+		if err != nil {
+			p.err = err
+			return
+		}
+		p.values.Set("authorization_details", encoded)
+	}
+}
+
+// WithSignedRequestObject 将完整的授权参数签名为请求对象，通过 request 参数传递（JAR，RFC 9101）
+// 授权地址只保留 client_id 与 request；签名密钥通过 WithRequestObjectSigningKey 配置，未配置时使用 client_secret 以 HS256 签名
+func WithSignedRequestObject() AuthorizationOption {
+	return func(p *authorizationParams) {
+		p.signedRequest = true
+	}
+}
+
+// WithPKCE 设置 code_challenge 与 code_challenge_method 参数（RFC 7636）
+// 交换令牌时需通过 WithCodeVerifier 传入同一 PKCE 的 Verifier
+func WithPKCE(pkce *PKCE) AuthorizationOption {
+	return func(p *authorizationParams) {
+		if pkce == nil || pkce.Challenge == "" {
+			p.err = fmt.Errorf("pkce challenge is required")
+			return
+		}
+		p.values.Set("code_challenge", pkce.Challenge)
+		p.values.Set("code_challenge_method", pkce.Method)
+	}
+}
+
+// WithAuthorizationParam 追加任意扩展参数
+// response_type、client_id、redirect_uri、state、scope、request、request_uri 以及 PKCE 参数由 SDK 维护，不能通过该选项设置
+func WithAuthorizationParam(key, value string) AuthorizationOption {
+	return func(p *authorizationParams) {
+		if reservedAuthorizationParams[key] {
+			p.err = fmt.Errorf("parameter %s cannot be set via WithAuthorizationParam", key)
+			return
+		}
+		p.values.Set(key, value)
+	}
+}
+
+// validateRedirectURI 校验 redirect_uri 是否在允许列表中
+// 允许列表中的 loopback 地址（http://127.0.0.1、http://[::1]）匹配时忽略端口（RFC 8252 7.3）
+func (c *Client) validateRedirectURI(redirectURI string) error {
+	for _, allowed := range append([]string{c.cfg.RedirectURI}, c.cfg.AllowedRedirectURIs...) {
+		if redirectURI == allowed || loopbackRedirectMatches(allowed, redirectURI) {
+			return nil
+		}
+	}
+	return fmt.Errorf("redirect_uri is not allowed: %s", redirectURI)
+}
@@ -0,0 +1,94 @@
+package goauthsdk
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// ParseAuthorizationResponse 解析并校验授权回调参数
+// 依次校验 state（常量时间比较）、iss（RFC 9207，配置 WithIssuer 后必须携带且一致）、error 参数，
+// 全部通过后返回授权码，可直接传给 ExchangeToken
+//
+// 参数:
+//   - values: 回调参数（通常为 r.URL.Query()）
+//   - expectedState: 发起授权时生成并保存（例如 Cookie、会话）的 state
+//
+// 返回:
+//   - *AuthorizationResponse: 授权响应
+//   - error: state 不一致返回 ErrStateMismatch；iss 缺失或不一致返回 ErrIssuerMismatch；
+//     授权服务器返回错误时返回 *AuthorizationError（可用 errors.Is 判断 ErrAccessDenied、ErrConsentRequired 等）
+//
+// 示例用法:
+//
+//	resp, err := client.ParseAuthorizationResponse(r.URL.Query(), expectedState)
+//	if errors.Is(err, goauthsdk.ErrAccessDenied) {
+//	    // 用户拒绝授权
+//	}
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	token, err := client.ExchangeToken(ctx, resp.Code)
+func (c *Client) ParseAuthorizationResponse(values url.Values, expectedState string) (*AuthorizationResponse, error) {
+	if expectedState == "" {
+		return nil, fmt.Errorf("expected_state is required")
+	}
+
+	state := values.Get("state")
+	if subtle.ConstantTimeCompare([]byte(state), []byte(expectedState)) != 1 {
+		return nil, ErrStateMismatch
+	}
+
+	// RFC 9207：配置了授权服务器标识时，回调必须携带一致的 iss（缺失同样视为不一致）
+	issuer := values.Get("iss")
+	if c.cfg.Issuer != "" && issuer != c.cfg.Issuer {
+		return nil, ErrIssuerMismatch
+	}
+
+	if errCode := values.Get("error"); errCode != "" {
+		return nil, &AuthorizationError{
+			Code:        errCode,
+			Description: values.Get("error_description"),
+			URI:         values.Get("error_uri"),
+			State:       state,
+		}
+	}
+
+	code := values.Get("code")
+	if code == "" {
+		return nil, fmt.Errorf("code is required")
+	}
+
+	return &AuthorizationResponse{
+		Code:         code,
+		State:        state,
+		Issuer:       issuer,
+		SessionState: values.Get("session_state"),
+	}, nil
+}
+
+// ParseAuthorizationCallback 从回调请求中解析并校验授权响应
+// GET 请求读取 URL query；POST 请求（response_mode=form_post）读取表单参数
+// 校验规则与 ParseAuthorizationResponse 相同
+//
+// 示例用法:
+//
+//	func callbackHandler(w http.ResponseWriter, r *http.Request) {
+//	    resp, err := client.ParseAuthorizationCallback(r, expectedState)
+//	    if err != nil {
+//	        http.Error(w, err.Error(), http.StatusBadRequest)
+//	        return
+//	    }
+//	    token, err := client.ExchangeToken(r.Context(), resp.Code)
+//	    // ...
+//	}
+func (c *Client) ParseAuthorizationCallback(r *http.Request, expectedState string) (*AuthorizationResponse, error) {
+	if r.Method == http.MethodPost {
+		if err := r.ParseForm(); err != nil {
+			return nil, fmt.Errorf("parse callback form: %w", err)
+		}
+		return c.ParseAuthorizationResponse(r.PostForm, expectedState)
+	}
+	return c.ParseAuthorizationResponse(r.URL.Query(), expectedState)
+}
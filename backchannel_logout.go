@@ -0,0 +1,248 @@
+package goauthsdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// backChannelLogoutEvent 是登出令牌 events 声明中必须包含的事件类型（OIDC Back-Channel Logout 2.4）
+	backChannelLogoutEvent = "http://schemas.openid.net/event/backchannel-logout"
+
+	// logoutTokenType 是登出令牌推荐的 JOSE typ 头部（OIDC Back-Channel Logout 2.4）
+	logoutTokenType = "logout+jwt"
+
+	// DefaultLogoutTokenMaxAge 是登出令牌的默认最大有效时长（按 iat 计算）
+	DefaultLogoutTokenMaxAge = 2 * time.Minute
+
+	// logoutTokenClockSkew 是允许登出令牌 iat 超前本地时间的范围
+	logoutTokenClockSkew = time.Minute
+)
+
+var (
+	// ErrInvalidLogoutToken 表示登出令牌无效（签名、iss、aud、iat、events、sid/sub、nonce 校验失败）
+	ErrInvalidLogoutToken = errors.New("invalid logout token")
+
+	// ErrLogoutTokenReplayed 表示登出令牌的 jti 已被处理过
+	ErrLogoutTokenReplayed = errors.New("logout token replayed")
+)
+
+// LogoutEvent 是校验通过的登出令牌携带的登出事件
+// SessionID 与 Subject 至少有一个非空：有 SessionID 时应结束该会话，仅有 Subject 时应结束该用户的全部会话
+type LogoutEvent struct {
+	Issuer    string    // 授权服务器标识
+	Subject   string    // 用户唯一标识，可能为空
+	SessionID string    // 授权服务器会话标识（sid），可能为空
+	JTI       string    // 登出令牌唯一标识
+	IssuedAt  time.Time // 签发时间
+}
+
+// logoutTokenClaims 是登出令牌的声明（OIDC Back-Channel Logout 2.4）
+type logoutTokenClaims struct {
+	SessionID string                     `json:"sid,omitempty"`
+	Events    map[string]json.RawMessage `json:"events"`
+	Nonce     *string                    `json:"nonce,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// VerifyLogoutToken 校验登出令牌（OIDC Back-Channel Logout 2.6），不做重放检测
+// 登出令牌由授权服务器使用访问令牌签名密钥签发，需配置 AccessTokenSecret 与 WithIssuer
+//
+// 校验内容：
+//   - HS256 签名，typ 为空或 logout+jwt
+//   - iss 与 WithIssuer 配置一致，aud 包含 client_id
+//   - iat 存在且在 maxAge 之内；exp 存在时未过期
+//   - events 包含 back-channel logout 事件，且其值为 JSON 对象
+//   - sid 与 sub 至少存在一个，jti 存在，且不包含 nonce
+//
+// 参数:
+//   - logoutToken: 登出令牌
+//   - maxAge: 登出令牌的最大有效时长，<= 0 时使用 DefaultLogoutTokenMaxAge
+func (c *Client) VerifyLogoutToken(logoutToken string, maxAge time.Duration) (*LogoutEvent, error) {
+	if c.jwtVerifier == nil {
+		return nil, ErrJWTNotConfigured
+	}
+	if c.cfg.Issuer == "" {
+		return nil, fmt.Errorf("issuer is required to verify logout token")
+	}
+	if logoutToken == "" {
+		return nil, fmt.Errorf("%w: logout_token is required", ErrInvalidLogoutToken)
+	}
+	if maxAge <= 0 {
+		maxAge = DefaultLogoutTokenMaxAge
+	}
+
+	var claims logoutTokenClaims
+	token, err := c.jwtVerifier.parseSignedJWT(logoutToken, &claims,
+		jwt.WithIssuer(c.cfg.Issuer),
+		jwt.WithAudience(c.cfg.ClientID),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(logoutTokenClockSkew),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidLogoutToken, err)
+	}
+
+	if typ, ok := token.Header["typ"].(string); ok && typ != logoutTokenType && typ != "JWT" {
+		return nil, fmt.Errorf("%w: unexpected typ %s", ErrInvalidLogoutToken, typ)
+	}
+	if claims.IssuedAt == nil || claims.IssuedAt.Before(time.Now().Add(-maxAge)) {
+		return nil, fmt.Errorf("%w: iat is missing or too old", ErrInvalidLogoutToken)
+	}
+	if claims.ID == "" {
+		return nil, fmt.Errorf("%w: jti is required", ErrInvalidLogoutToken)
+	}
+	if claims.SessionID == "" && claims.Subject == "" {
+		return nil, fmt.Errorf("%w: sid or sub is required", ErrInvalidLogoutToken)
+	}
+	if claims.Nonce != nil {
+		return nil, fmt.Errorf("%w: nonce is not allowed", ErrInvalidLogoutToken)
+	}
+	event, ok := claims.Events[backChannelLogoutEvent]
+	var eventValue map[string]any
+	if !ok || json.Unmarshal(event, &eventValue) != nil || eventValue == nil {
+		return nil, fmt.Errorf("%w: back-channel logout event is required", ErrInvalidLogoutToken)
+	}
+
+	return &LogoutEvent{
+		Issuer:    claims.Issuer,
+		Subject:   claims.Subject,
+		SessionID: claims.SessionID,
+		JTI:       claims.ID,
+		IssuedAt:  claims.IssuedAt.Time,
+	}, nil
+}
+
+// BackChannelLogoutHandler 接收授权服务器推送的登出令牌（OIDC Back-Channel Logout）
+// 校验通过且未重放时调用应用提供的回调结束对应的本地会话
+type BackChannelLogoutHandler struct {
+	client   *Client
+	onLogout func(ctx context.Context, event *LogoutEvent) error
+	replay   ReplayCache
+	maxAge   time.Duration
+}
+
+// BackChannelLogoutOption 用于配置 BackChannelLogoutHandler 的可选参数
+type BackChannelLogoutOption func(*BackChannelLogoutHandler)
+
+// WithLogoutReplayCache 设置用于检测登出令牌重放的缓存，多实例部署时应使用共享存储实现
+// 不设置时使用 NewMemoryReplayCache
+func WithLogoutReplayCache(cache ReplayCache) BackChannelLogoutOption {
+	return func(h *BackChannelLogoutHandler) {
+		h.replay = cache
+	}
+}
+
+// WithLogoutTokenMaxAge 设置登出令牌的最大有效时长，默认 DefaultLogoutTokenMaxAge
+func WithLogoutTokenMaxAge(d time.Duration) BackChannelLogoutOption {
+	return func(h *BackChannelLogoutHandler) {
+		h.maxAge = d
+	}
+}
+
+// NewBackChannelLogoutHandler 创建后端通道登出处理器
+// 将其注册为客户端的 backchannel_logout_uri，例如 mux.Handle("/backchannel-logout", handler)
+//
+// 参数:
+//   - client: 配置了 AccessTokenSecret 与 WithIssuer 的 Client
+//   - onLogout: 登出回调，应结束与 event.SessionID（或 event.Subject 的全部）匹配的本地会话；返回错误时响应 400
+//   - opts: 可选配置
+//
+// 示例用法:
+//
+//	handler, err := goauthsdk.NewBackChannelLogoutHandler(client,
+//	    func(ctx context.Context, event *goauthsdk.LogoutEvent) error {
+//	        if event.SessionID != "" {
+//	            return sessions.DeleteBySID(ctx, event.SessionID)
+//	        }
+//	        return sessions.DeleteBySubject(ctx, event.Subject)
+//	    },
+//	)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	mux.Handle("/backchannel-logout", handler)
+func NewBackChannelLogoutHandler(client *Client, onLogout func(ctx context.Context, event *LogoutEvent) error, opts ...BackChannelLogoutOption) (*BackChannelLogoutHandler, error) {
+	if client == nil {
+		return nil, fmt.Errorf("client is required")
+	}
+	if onLogout == nil {
+		return nil, fmt.Errorf("logout callback is required")
+	}
+	if client.jwtVerifier == nil {
+		return nil, ErrJWTNotConfigured
+	}
+	if client.cfg.Issuer == "" {
+		return nil, fmt.Errorf("issuer is required to verify logout token")
+	}
+
+	h := &BackChannelLogoutHandler{
+		client:   client,
+		onLogout: onLogout,
+		maxAge:   DefaultLogoutTokenMaxAge,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	if h.replay == nil {
+		h.replay = NewMemoryReplayCache()
+	}
+	return h, nil
+}
+
+// ServeHTTP 处理 POST application/x-www-form-urlencoded 的 logout_token
+// 成功返回 200；令牌无效、重放或回调失败时返回 400 与 RFC 6749 风格的错误（OIDC Back-Channel Logout 2.8）
+// 回调失败时撤销 jti 的重放记录，授权服务器可以重试同一登出令牌
+func (h *BackChannelLogoutHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "no-store")
+
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		writeLogoutError(w, "invalid_request", "malformed form body")
+		return
+	}
+
+	event, err := h.client.VerifyLogoutToken(r.PostForm.Get("logout_token"), h.maxAge)
+	if err != nil {
+		writeLogoutError(w, "invalid_request", "invalid logout token")
+		return
+	}
+
+	// 重放检测：同一 jti 只处理一次
+	replayKey := event.Issuer + "\x00" + event.JTI
+	fresh, err := h.replay.Add(r.Context(), replayKey, event.IssuedAt.Add(h.maxAge+logoutTokenClockSkew))
+	if err != nil {
+		writeLogoutError(w, "logout_failed", "failed to check logout token replay")
+		return
+	}
+	if !fresh {
+		writeLogoutError(w, "invalid_request", ErrLogoutTokenReplayed.Error())
+		return
+	}
+
+	if err := h.onLogout(r.Context(), event); err != nil {
+		// 回调失败时撤销 jti 记录，授权服务器重试同一登出令牌时仍会处理
+		_ = h.replay.Delete(context.WithoutCancel(r.Context()), replayKey)
+		writeLogoutError(w, "logout_failed", "failed to terminate session")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// writeLogoutError 写出登出失败响应（400，{error, error_description}）
+func writeLogoutError(w http.ResponseWriter, code, description string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	_ = json.NewEncoder(w).Encode(oauthErrorResponse{Error: code, ErrorDescription: description})
+}
@@ -0,0 +1,192 @@
+package goauthsdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testIssuer = "https://auth.example.com"
+
+// newLogoutTestClient 创建可校验登出令牌的 Client
+func newLogoutTestClient(t *testing.T) *Client {
+	t.Helper()
+	client, err := NewClient("https://portal.example.com", "https://auth.example.com", "client-1", "client-secret",
+		"https://portal.example.com/callback", WithAccessTokenSecret(testAccessTokenSecret), WithIssuer(testIssuer))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+// signLogoutToken 使用访问令牌密钥签发登出令牌；overrides 中值为 nil 的声明会被删除
+func signLogoutToken(t *testing.T, overrides map[string]any) string {
+	t.Helper()
+	claims := jwt.MapClaims{
+		"iss":    testIssuer,
+		"aud":    "client-1",
+		"iat":    time.Now().Unix(),
+		"jti":    "logout-1",
+		"sid":    "session-1",
+		"events": map[string]any{backChannelLogoutEvent: map[string]any{}},
+	}
+	for name, value := range overrides {
+		if value == nil {
+			delete(claims, name)
+		} else {
+			claims[name] = value
+		}
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	token.Header["typ"] = logoutTokenType
+	signed, err := token.SignedString([]byte(testAccessTokenSecret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func TestVerifyLogoutToken(t *testing.T) {
+	client := newLogoutTestClient(t)
+
+	// 同一密钥签发的访问令牌即使 iss、aud 匹配也不能作为登出令牌
+	accessToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"token_type": "access",
+		"iss":        testIssuer,
+		"aud":        "client-1",
+		"sub":        "user-1",
+		"jti":        "access-1",
+		"iat":        time.Now().Unix(),
+		"exp":        time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte(testAccessTokenSecret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	forged, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"iss": testIssuer, "aud": "client-1", "iat": time.Now().Unix(), "jti": "logout-1", "sid": "session-1",
+		"events": map[string]any{backChannelLogoutEvent: map[string]any{}},
+	}).SignedString([]byte("other-secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{"valid with sid", signLogoutToken(t, nil), false},
+		{"valid with sub only", signLogoutToken(t, map[string]any{"sid": nil, "sub": "user-1"}), false},
+		{"missing events", signLogoutToken(t, map[string]any{"events": nil}), true},
+		{"other event", signLogoutToken(t, map[string]any{"events": map[string]any{"urn:example:event": map[string]any{}}}), true},
+		{"event not an object", signLogoutToken(t, map[string]any{"events": map[string]any{backChannelLogoutEvent: "logout"}}), true},
+		{"nonce present", signLogoutToken(t, map[string]any{"nonce": "n-1"}), true},
+		{"neither sid nor sub", signLogoutToken(t, map[string]any{"sid": nil}), true},
+		{"missing jti", signLogoutToken(t, map[string]any{"jti": nil}), true},
+		{"wrong aud", signLogoutToken(t, map[string]any{"aud": "client-2"}), true},
+		{"wrong iss", signLogoutToken(t, map[string]any{"iss": "https://evil.example.com"}), true},
+		{"missing iat", signLogoutToken(t, map[string]any{"iat": nil}), true},
+		{"stale iat", signLogoutToken(t, map[string]any{"iat": time.Now().Add(-DefaultLogoutTokenMaxAge - time.Minute).Unix()}), true},
+		{"expired", signLogoutToken(t, map[string]any{"exp": time.Now().Add(-2 * logoutTokenClockSkew).Unix()}), true},
+		{"access token signed with same secret", accessToken, true},
+		{"signed with other secret", forged, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := client.VerifyLogoutToken(tt.token, 0)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidLogoutToken) {
+					t.Fatalf("got %v, want ErrInvalidLogoutToken", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if event.Issuer != testIssuer || event.JTI != "logout-1" || (event.SessionID == "" && event.Subject == "") {
+				t.Errorf("event = %+v", event)
+			}
+		})
+	}
+}
+
+func TestBackChannelLogoutHandler(t *testing.T) {
+	client := newLogoutTestClient(t)
+
+	var events []*LogoutEvent
+	failNext := false
+	handler, err := NewBackChannelLogoutHandler(client, func(ctx context.Context, event *LogoutEvent) error {
+		if failNext {
+			failNext = false
+			return errors.New("session store unavailable")
+		}
+		events = append(events, event)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	post := func(token string) *httptest.ResponseRecorder {
+		form := url.Values{"logout_token": {token}}
+		r := httptest.NewRequest(http.MethodPost, "/backchannel-logout", strings.NewReader(form.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	token := signLogoutToken(t, nil)
+	if w := post(token); w.Code != http.StatusOK {
+		t.Fatalf("first delivery: status = %d, body = %s", w.Code, w.Body)
+	}
+	if len(events) != 1 || events[0].SessionID != "session-1" {
+		t.Fatalf("events = %+v", events)
+	}
+
+	// 同一 jti 重放被拒绝
+	w := post(token)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), ErrLogoutTokenReplayed.Error()) {
+		t.Fatalf("replay: status = %d, body = %s", w.Code, w.Body)
+	}
+
+	// 无效令牌返回 400，不调用回调
+	if w := post(signLogoutToken(t, map[string]any{"jti": "logout-2", "nonce": "n-1"})); w.Code != http.StatusBadRequest {
+		t.Fatalf("invalid token: status = %d", w.Code)
+	}
+	if w := post(""); w.Code != http.StatusBadRequest {
+		t.Fatalf("missing token: status = %d", w.Code)
+	}
+
+	// 回调失败后撤销 jti 记录，授权服务器重试同一令牌时仍会处理
+	retried := signLogoutToken(t, map[string]any{"jti": "logout-3"})
+	failNext = true
+	w = post(retried)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "logout_failed") {
+		t.Fatalf("failed callback: status = %d, body = %s", w.Code, w.Body)
+	}
+	if w := post(retried); w.Code != http.StatusOK {
+		t.Fatalf("retry after failed callback: status = %d, body = %s", w.Code, w.Body)
+	}
+	if len(events) != 2 || events[1].JTI != "logout-3" {
+		t.Fatalf("events = %+v", events)
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/backchannel-logout", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, r)
+	if rec.Code != http.StatusMethodNotAllowed || rec.Header().Get("Allow") != http.MethodPost {
+		t.Fatalf("GET: status = %d, Allow = %q", rec.Code, rec.Header().Get("Allow"))
+	}
+	if rec.Header().Get("Cache-Control") != "no-store" {
+		t.Error("Cache-Control not set")
+	}
+}
@@ -9,8 +9,11 @@ import (
 // Client 是 goauth SDK 的客户端
 // 封装了 OAuth 授权码模式的常用操作
 type Client struct {
-	cfg         configx.Config
-	jwtVerifier *JWTVerifier
+	cfg           configx.Config
+	jwtVerifier   *JWTVerifier
+	clientAuth    clientAuthenticator
+	exchangeCache *tokenExchangeCache
+	dpop          *dpopSigner
 }
 
 // NewClient 创建一个新的 goauth SDK 客户端
@@ -19,7 +22,7 @@ type Client struct {
 //   - frontendBaseURL: 前端站点基础地址，例如 https://portal.example.com
 //   - backendBaseURL: goauth 后端服务基础地址，例如 https://auth.example.com
 //   - clientID: OAuth 客户端 ID
-//   - clientSecret: OAuth 客户端密钥
+//   - clientSecret: OAuth 客户端密钥（使用 WithPrivateKeyJWT 或 mTLS 认证时可为空）
 //   - redirectURI: OAuth 回调地址，必须在客户端注册的回调白名单中
 //
 // 可选参数通过 ClientOption 传入:
@@ -27,6 +30,16 @@ type Client struct {
 //   - WithAccessTokenSecret: 访问令牌签名密钥（用于离线验签）
 //   - WithRefreshTokenSecret: 刷新令牌签名密钥（用于离线验签）
 //   - WithJWTSecrets: 同时设置访问/刷新令牌密钥
+//   - WithIssuer: 授权服务器标识（用于校验回调 iss 参数）
+//   - WithAllowedRedirectURIs: 额外允许的回调地址（用于单次请求覆盖 redirect_uri）
+//   - WithClientAuthMethod: 客户端认证方式（client_secret_basic / client_secret_post / client_secret_jwt）
+//   - WithPrivateKeyJWT: 使用私钥签发客户端断言进行认证（private_key_jwt）
+//   - WithTLSClientAuth / WithSelfSignedTLSClientAuth: 使用 mTLS 客户端证书进行认证
+//   - WithTLSClientCertificate: 出示客户端证书以获取证书绑定令牌（不改变认证方式）
+//   - WithTLSRootCAs: 校验授权服务器证书的根证书池
+//   - WithMTLSEndpointAliases: mTLS 端点别名（仅在使用 mTLS 时生效）
+//   - WithDPoP: 启用 DPoP 令牌绑定
+//   - WithRequestObjectSigningKey: 签名请求对象（JAR）使用的私钥
 //
 // 示例用法:
 //
@@ -62,7 +75,16 @@ func NewClient(
 	}
 	configx.Normalize(&cfg)
 
-	client := &Client{cfg: cfg}
+	clientAuth, err := newClientAuthenticator(&cfg)
+	if err != nil {
+		return nil, fmt.Errorf("create client authenticator: %w", err)
+	}
+
+	client := &Client{
+		cfg:           cfg,
+		clientAuth:    clientAuth,
+		exchangeCache: newTokenExchangeCache(),
+	}
 
 	// 若配置了 AccessTokenSecret 或 RefreshTokenSecret，创建 JWTVerifier 用于离线验签
 	if cfg.AccessTokenSecret != "" || cfg.RefreshTokenSecret != "" {
@@ -73,6 +95,15 @@ func NewClient(
 		client.jwtVerifier = verifier
 	}
 
+	// 若配置了 DPoP 密钥，令牌请求与资源请求附加 DPoP 证明
+	if cfg.DPoPKey != nil {
+		signer, err := newDPoPSigner(cfg.DPoPKey)
+		if err != nil {
+			return nil, fmt.Errorf("create dpop signer: %w", err)
+		}
+		client.dpop = signer
+	}
+
 	return client, nil
 }
 
@@ -0,0 +1,232 @@
+package goauthsdk
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/3086953492/goauthsdk/internal/configx"
+	"github.com/3086953492/goauthsdk/internal/cryptox"
+	"github.com/3086953492/goauthsdk/internal/jwtx"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// 客户端认证方式（token_endpoint_auth_method），通过 WithClientAuthMethod 选择
+const (
+	// ClientAuthSecretBasic 通过 HTTP Basic Auth 传递 client_id 与 client_secret（默认）
+	ClientAuthSecretBasic = "client_secret_basic"
+
+	// ClientAuthSecretPost 通过表单参数传递 client_id 与 client_secret
+	ClientAuthSecretPost = "client_secret_post"
+
+	// ClientAuthSecretJWT 使用 client_secret 以 HS256 签发客户端断言（RFC 7523）
+	ClientAuthSecretJWT = "client_secret_jwt"
+
+	// ClientAuthPrivateKeyJWT 使用私钥（RSA / EC）签发客户端断言（RFC 7523），无需 client_secret
+	ClientAuthPrivateKeyJWT = "private_key_jwt"
+
+	// ClientAuthTLS 使用 PKI 签发的 mTLS 客户端证书认证（RFC 8705 2.1），无需 client_secret
+	ClientAuthTLS = "tls_client_auth"
+
+	// ClientAuthSelfSignedTLS 使用自签名 mTLS 客户端证书认证（RFC 8705 2.2），无需 client_secret
+	ClientAuthSelfSignedTLS = "self_signed_tls_client_auth"
+
+	// ClientAuthNone 公开客户端（原生应用、命令行工具），只传递 client_id，需配合 PKCE 使用（RFC 8252 8.5）
+	ClientAuthNone = "none"
+)
+
+const (
+	// clientAssertionType 是 JWT 客户端断言的 client_assertion_type（RFC 7523 2.2）
+	clientAssertionType = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+
+	// clientAssertionLifetime 是客户端断言的有效期，断言仅用于单次请求，保持尽量短
+	clientAssertionLifetime = time.Minute
+)
+
+// clientAuthenticator 为发往授权服务器的请求附加客户端认证信息
+// form 尚未编码，实现可以追加表单参数；header 会被复制到最终请求上
+type clientAuthenticator interface {
+	apply(form url.Values, header http.Header) error
+}
+
+// newClientAuthenticator 根据配置创建客户端认证器
+func newClientAuthenticator(cfg *configx.Config) (clientAuthenticator, error) {
+	// 断言的 aud：优先使用 issuer，否则使用 token 端点地址（RFC 7523 3）
+	audience := cfg.Issuer
+	if audience == "" {
+		audience = cfg.BackendBaseURL + tokenEndpointPath
+	}
+
+	switch cfg.ClientAuthMethod {
+	case ClientAuthSecretBasic:
+		return &clientSecretBasic{clientID: cfg.ClientID, clientSecret: cfg.ClientSecret}, nil
+	case ClientAuthSecretPost:
+		return &clientSecretPost{clientID: cfg.ClientID, clientSecret: cfg.ClientSecret}, nil
+	case ClientAuthSecretJWT:
+		return &clientAssertion{
+			clientID: cfg.ClientID,
+			audience: audience,
+			key:      []byte(cfg.ClientSecret),
+		}, nil
+	case ClientAuthPrivateKeyJWT:
+		if cfg.ClientAssertionKey == nil {
+			return nil, fmt.Errorf("private key is required for %s", ClientAuthPrivateKeyJWT)
+		}
+		switch cfg.ClientAssertionKey.(type) {
+		case *rsa.PrivateKey, *ecdsa.PrivateKey:
+		default:
+			return nil, fmt.Errorf("unsupported private key type: %T", cfg.ClientAssertionKey)
+		}
+		return &clientAssertion{
+			clientID: cfg.ClientID,
+			audience: audience,
+			key:      cfg.ClientAssertionKey,
+			keyID:    cfg.ClientAssertionKeyID,
+		}, nil
+	case ClientAuthTLS, ClientAuthSelfSignedTLS:
+		return &tlsClientAuth{clientID: cfg.ClientID}, nil
+	case ClientAuthNone:
+		return &publicClientAuth{clientID: cfg.ClientID}, nil
+	}
+	return nil, fmt.Errorf("unsupported client auth method: %s", cfg.ClientAuthMethod)
+}
+
+// newClientAuthRequest 创建携带客户端认证信息的表单 POST 请求
+// token、introspect、revoke 等需要客户端认证的接口统一通过该函数创建请求
+func newClientAuthRequest(ctx context.Context, c *Client, endpoint string, form url.Values) (*http.Request, error) {
+	base := cloneValues(form)
+	header := http.Header{}
+	if err := c.clientAuth.apply(form, header); err != nil {
+		return nil, fmt.Errorf("apply client auth: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	// 设置 Content-Type
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	for name, values := range header {
+		req.Header[name] = values
+	}
+
+	// 重放请求体（例如 DPoP nonce 重试）时重新生成客户端认证参数，
+	// 避免重复使用 jti 相同的客户端断言（RFC 7523 3）
+	req.GetBody = func() (io.ReadCloser, error) {
+		fresh := cloneValues(base)
+		if err := c.clientAuth.apply(fresh, http.Header{}); err != nil {
+			return nil, fmt.Errorf("apply client auth: %w", err)
+		}
+		return io.NopCloser(strings.NewReader(fresh.Encode())), nil
+	}
+
+	return req, nil
+}
+
+// cloneValues 深拷贝表单参数
+func cloneValues(values url.Values) url.Values {
+	cloned := make(url.Values, len(values))
+	for key, vs := range values {
+		cloned[key] = slices.Clone(vs)
+	}
+	return cloned
+}
+
+// clientSecretBasic 实现 client_secret_basic
+type clientSecretBasic struct {
+	clientID     string
+	clientSecret string
+}
+
+// apply 设置 Basic Auth（client_id 和 client_secret）
+func (a *clientSecretBasic) apply(form url.Values, header http.Header) error {
+	req := http.Request{Header: header}
+	req.SetBasicAuth(a.clientID, a.clientSecret)
+	return nil
+}
+
+// clientSecretPost 实现 client_secret_post
+type clientSecretPost struct {
+	clientID     string
+	clientSecret string
+}
+
+// apply 在表单中追加 client_id 与 client_secret
+func (a *clientSecretPost) apply(form url.Values, header http.Header) error {
+	form.Set("client_id", a.clientID)
+	form.Set("client_secret", a.clientSecret)
+	return nil
+}
+
+// clientAssertion 实现 client_secret_jwt 与 private_key_jwt
+// key 为 []byte 时使用 HS256，为 RSA / EC 私钥时使用对应的非对称算法
+type clientAssertion struct {
+	clientID string
+	audience string
+	key      any
+	keyID    string
+}
+
+// apply 签发一次性客户端断言并追加到表单
+func (a *clientAssertion) apply(form url.Values, header http.Header) error {
+	jti, err := cryptox.RandomString(16)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	claims := jwt.RegisteredClaims{
+		Issuer:    a.clientID,
+		Subject:   a.clientID,
+		Audience:  jwt.ClaimStrings{a.audience},
+		ID:        jti,
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(clientAssertionLifetime)),
+	}
+
+	var headers map[string]any
+	if a.keyID != "" {
+		headers = map[string]any{"kid": a.keyID}
+	}
+
+	assertion, err := jwtx.Sign(claims, a.key, headers)
+	if err != nil {
+		return fmt.Errorf("sign client assertion: %w", err)
+	}
+
+	form.Set("client_id", a.clientID)
+	form.Set("client_assertion_type", clientAssertionType)
+	form.Set("client_assertion", assertion)
+	return nil
+}
+
+// tlsClientAuth 实现 tls_client_auth 与 self_signed_tls_client_auth
+// 客户端身份由 TLS 握手中的证书证明，请求中只需携带 client_id
+type tlsClientAuth struct {
+	clientID string
+}
+
+// apply 在表单中追加 client_id
+func (a *tlsClientAuth) apply(form url.Values, header http.Header) error {
+	form.Set("client_id", a.clientID)
+	return nil
+}
+
+// publicClientAuth 实现 none：公开客户端不持有凭据，请求中只携带 client_id
+type publicClientAuth struct {
+	clientID string
+}
+
+// apply 在表单中追加 client_id
+func (a *publicClientAuth) apply(form url.Values, header http.Header) error {
+	form.Set("client_id", a.clientID)
+	return nil
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/3086953492/goauthsdk"
+)
+
+// newClient 根据配置档创建 SDK 客户端
+// 未配置 client_secret 时按公开客户端（none）认证；未配置 redirect_uri 时使用 loopback 地址
+func newClient(p *profile) (*goauthsdk.Client, error) {
+	redirectURI := p.RedirectURI
+	if redirectURI == "" {
+		redirectURI = defaultRedirectURI
+	}
+
+	var opts []goauthsdk.ClientOption
+	if p.ClientSecret == "" {
+		opts = append(opts, goauthsdk.WithClientAuthMethod(goauthsdk.ClientAuthNone))
+	}
+	if p.Issuer != "" {
+		opts = append(opts, goauthsdk.WithIssuer(p.Issuer))
+	}
+	if p.AccessTokenSecret != "" || p.RefreshTokenSecret != "" {
+		opts = append(opts, goauthsdk.WithJWTSecrets(p.AccessTokenSecret, p.RefreshTokenSecret))
+	}
+
+	client, err := goauthsdk.NewClient(p.FrontendBaseURL, p.BackendBaseURL, p.ClientID, p.ClientSecret, redirectURI, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("create client: %w", err)
+	}
+	return client, nil
+}
+
+// session 是一次命令使用的配置档、客户端与令牌存储
+type session struct {
+	name      string
+	tokenPath string
+	profile   *profile
+	client    *goauthsdk.Client
+	store     goauthsdk.TokenStore
+	refresher *goauthsdk.TokenRefresher
+}
+
+// openSession 加载配置档并创建客户端；令牌保存在 tokens/<profile>.json（权限 0600）
+func (a *app) openSession() (*session, error) {
+	name, p, err := a.loadProfile()
+	if err != nil {
+		return nil, err
+	}
+	client, err := newClient(p)
+	if err != nil {
+		return nil, err
+	}
+
+	path, err := a.tokenPath(name)
+	if err != nil {
+		return nil, err
+	}
+	store, err := goauthsdk.NewFileTokenStore(path)
+	if err != nil {
+		return nil, err
+	}
+	refresher, err := goauthsdk.NewTokenRefresher(client, store, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &session{name: name, tokenPath: path, profile: p, client: client, store: store, refresher: refresher}, nil
+}
+
+// deleteToken 删除配置档保存的令牌及其令牌文件
+func (s *session) deleteToken(ctx context.Context) error {
+	if err := s.refresher.Delete(ctx); err != nil {
+		return err
+	}
+	if err := os.Remove(s.tokenPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("remove token file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"time"
+
+	"github.com/3086953492/goauthsdk"
+)
+
+// runLogin 通过浏览器完成授权码登录，并将令牌保存到配置档的令牌文件
+func (a *app) runLogin(ctx context.Context, args []string) error {
+	flags := a.newFlagSet("login", "login [-scope s] [-timeout d] [-no-browser]")
+	scope := flags.String("scope", "", "申请的 scope（默认使用配置档中的 scope）")
+	timeout := flags.Duration("timeout", goauthsdk.DefaultLoopbackTimeout, "等待浏览器回调的最长时间")
+	noBrowser := flags.Bool("no-browser", false, "只打印授权地址，不自动打开浏览器")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	s, err := a.openSession()
+	if err != nil {
+		return err
+	}
+	if *scope == "" {
+		*scope = s.profile.Scope
+	}
+
+	opener := func(authURL string) error {
+		fmt.Fprintf(a.stderr, "在浏览器中打开以下地址完成登录：\n\n  %s\n\n", authURL)
+		if !*noBrowser {
+			// 打开失败时用户仍可手动复制地址
+			_ = openBrowser(authURL)
+		}
+		return nil
+	}
+
+	token, err := s.client.LoopbackLogin(ctx, *scope,
+		goauthsdk.WithBrowserOpener(opener),
+		goauthsdk.WithLoopbackTimeout(*timeout),
+	)
+	if err != nil {
+		return fmt.Errorf("login: %w", err)
+	}
+
+	stored, err := s.refresher.Save(ctx, token)
+	if err != nil {
+		return fmt.Errorf("save token: %w", err)
+	}
+	fmt.Fprintf(a.stderr, "登录成功，令牌已保存到配置档 %s\n", s.name)
+	return a.print(describeStoredToken(stored))
+}
+
+// runLogout 撤销并删除本地保存的令牌
+func (a *app) runLogout(ctx context.Context, args []string) error {
+	flags := a.newFlagSet("logout", "logout")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	s, err := a.openSession()
+	if err != nil {
+		return err
+	}
+	// 直接读取存储，避免为了登出而刷新令牌
+	stored, err := s.store.Load(ctx, s.name)
+	if err != nil && !errors.Is(err, goauthsdk.ErrTokenNotFound) {
+		return err
+	}
+
+	// 本地令牌总是删除；撤销失败只提示，不阻止登出
+	if stored != nil {
+		resp := &goauthsdk.TokenResponse{
+			AccessToken:  goauthsdk.AccessTokenInfo{AccessToken: stored.AccessToken},
+			RefreshToken: goauthsdk.RefreshTokenInfo{RefreshToken: stored.RefreshToken},
+		}
+		if err := s.client.Logout(ctx, resp); err != nil {
+			fmt.Fprintf(a.stderr, "撤销令牌失败: %v\n", err)
+		}
+	}
+	if err := s.deleteToken(ctx); err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stderr, "已登出配置档 %s\n", s.name)
+	return nil
+}
+
+// openBrowser 使用系统默认浏览器打开地址
+func openBrowser(u string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", u)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", u)
+	default:
+		cmd = exec.Command("xdg-open", u)
+	}
+	return cmd.Start()
+}
+
+// describeStoredToken 将保存的令牌转换为输出格式，过期时间以 RFC 3339 展示
+func describeStoredToken(t *goauthsdk.StoredToken) map[string]any {
+	out := map[string]any{
+		"access_token":            t.AccessToken,
+		"token_type":              t.TokenType,
+		"scope":                   t.Scope,
+		"access_token_expires_at": formatUnix(t.AccessTokenExpiresAt),
+	}
+	if t.RefreshToken != "" {
+		out["refresh_token"] = t.RefreshToken
+		out["refresh_token_expires_at"] = formatUnix(t.RefreshTokenExpiresAt)
+	}
+	return out
+}
+
+// formatUnix 将 Unix 时间戳格式化为 RFC 3339，0 表示未知
+func formatUnix(ts int64) string {
+	if ts == 0 {
+		return ""
+	}
+	return time.Unix(ts, 0).Format(time.RFC3339)
+}
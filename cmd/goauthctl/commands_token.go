@@ -0,0 +1,195 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// runToken 处理 token 子命令
+func (a *app) runToken(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		fmt.Fprintln(a.stderr, "用法: goauthctl token client-credentials|refresh|show")
+		return errUsage
+	}
+
+	switch args[0] {
+	case "client-credentials":
+		return a.runTokenClientCredentials(ctx, args[1:])
+	case "refresh":
+		return a.runTokenRefresh(ctx, args[1:])
+	case "show":
+		return a.runTokenShow(ctx, args[1:])
+	}
+	fmt.Fprintf(a.stderr, "未知命令: token %s\n", args[0])
+	return errUsage
+}
+
+// runTokenClientCredentials 使用客户端凭证模式获取令牌（不保存，客户端凭证令牌可随时重新获取）
+func (a *app) runTokenClientCredentials(ctx context.Context, args []string) error {
+	flags := a.newFlagSet("token client-credentials", "token client-credentials [-scope s]")
+	scope := flags.String("scope", "", "申请的 scope")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	s, err := a.openSession()
+	if err != nil {
+		return err
+	}
+	token, err := s.client.ClientCredentialsToken(ctx, *scope)
+	if err != nil {
+		return fmt.Errorf("client credentials: %w", err)
+	}
+	return a.print(token)
+}
+
+// runTokenRefresh 使用保存的刷新令牌（或 -refresh-token 指定的令牌）刷新访问令牌
+func (a *app) runTokenRefresh(ctx context.Context, args []string) error {
+	flags := a.newFlagSet("token refresh", "token refresh [-refresh-token t]")
+	refreshToken := flags.String("refresh-token", "", "刷新令牌（默认使用保存的刷新令牌，结果会保存）")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	s, err := a.openSession()
+	if err != nil {
+		return err
+	}
+
+	// 显式传入的刷新令牌只输出结果，不覆盖保存的令牌
+	if *refreshToken != "" {
+		token, err := s.client.RefreshToken(ctx, *refreshToken)
+		if err != nil {
+			return fmt.Errorf("refresh token: %w", err)
+		}
+		return a.print(token)
+	}
+
+	stored, err := s.store.Load(ctx, s.name)
+	if err != nil {
+		return fmt.Errorf("load token: %w", err)
+	}
+	if stored.RefreshToken == "" {
+		return fmt.Errorf("saved token has no refresh token, run goauthctl login")
+	}
+	token, err := s.client.RefreshToken(ctx, stored.RefreshToken)
+	if err != nil {
+		return fmt.Errorf("refresh token: %w", err)
+	}
+	saved, err := s.refresher.Save(ctx, token)
+	if err != nil {
+		return fmt.Errorf("save token: %w", err)
+	}
+	return a.print(describeStoredToken(saved))
+}
+
+// runTokenShow 显示保存的令牌（不刷新）
+func (a *app) runTokenShow(ctx context.Context, args []string) error {
+	flags := a.newFlagSet("token show", "token show")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	s, err := a.openSession()
+	if err != nil {
+		return err
+	}
+	stored, err := s.store.Load(ctx, s.name)
+	if err != nil {
+		return fmt.Errorf("load token: %w", err)
+	}
+	return a.print(describeStoredToken(stored))
+}
+
+// runIntrospect 内省令牌，未指定令牌时使用保存的访问令牌
+func (a *app) runIntrospect(ctx context.Context, args []string) error {
+	flags := a.newFlagSet("introspect", "introspect [-hint access_token|refresh_token] [token]")
+	hint := flags.String("hint", "", "token_type_hint")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	s, err := a.openSession()
+	if err != nil {
+		return err
+	}
+	token, storedHint, err := a.tokenArg(ctx, s, flags.Args(), false)
+	if err != nil {
+		return err
+	}
+	if *hint == "" {
+		*hint = storedHint
+	}
+
+	resp, err := s.client.IntrospectTokenWithHint(ctx, token, *hint)
+	if err != nil {
+		return fmt.Errorf("introspect: %w", err)
+	}
+	return a.print(resp)
+}
+
+// runRevoke 撤销令牌，未指定令牌时撤销保存的刷新令牌
+func (a *app) runRevoke(ctx context.Context, args []string) error {
+	flags := a.newFlagSet("revoke", "revoke [-hint access_token|refresh_token] [token]")
+	hint := flags.String("hint", "", "token_type_hint")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	s, err := a.openSession()
+	if err != nil {
+		return err
+	}
+	token, storedHint, err := a.tokenArg(ctx, s, flags.Args(), true)
+	if err != nil {
+		return err
+	}
+	if *hint == "" {
+		*hint = storedHint
+	}
+
+	if err := s.client.RevokeTokenWithHint(ctx, token, *hint); err != nil {
+		return fmt.Errorf("revoke: %w", err)
+	}
+
+	// 撤销的是保存的令牌时同时删除本地令牌文件，避免后续命令继续使用已失效的令牌
+	if len(flags.Args()) == 0 {
+		if err := s.deleteToken(ctx); err != nil {
+			return err
+		}
+		fmt.Fprintf(a.stderr, "令牌已撤销，已删除配置档 %s 保存的令牌\n", s.name)
+		return nil
+	}
+	fmt.Fprintln(a.stderr, "令牌已撤销")
+	return nil
+}
+
+// tokenArg 返回命令行传入的令牌；未传入时读取保存的令牌，并返回与之对应的 token_type_hint
+// preferRefresh 为 true 时优先返回保存的刷新令牌，否则返回（必要时自动刷新的）访问令牌；
+// 命令行传入令牌时无法判断类型，hint 为空
+func (a *app) tokenArg(ctx context.Context, s *session, args []string, preferRefresh bool) (token, hint string, err error) {
+	if len(args) > 1 {
+		return "", "", fmt.Errorf("too many arguments: %s", strings.Join(args, " "))
+	}
+	if len(args) == 1 {
+		return args[0], "", nil
+	}
+
+	if preferRefresh {
+		stored, err := s.store.Load(ctx, s.name)
+		if err != nil {
+			return "", "", fmt.Errorf("load token: %w", err)
+		}
+		if stored.RefreshToken != "" {
+			return stored.RefreshToken, "refresh_token", nil
+		}
+		return stored.AccessToken, "access_token", nil
+	}
+
+	token, err = s.refresher.AccessToken(ctx)
+	if err != nil {
+		return "", "", fmt.Errorf("load token: %w", err)
+	}
+	return token, "access_token", nil
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"fmt"
+)
+
+// runUserInfo 获取当前访问令牌对应的用户信息
+func (a *app) runUserInfo(ctx context.Context, args []string) error {
+	flags := a.newFlagSet("userinfo", "userinfo [token]")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	s, err := a.openSession()
+	if err != nil {
+		return err
+	}
+	token, _, err := a.tokenArg(ctx, s, flags.Args(), false)
+	if err != nil {
+		return err
+	}
+
+	info, err := s.client.UserInfo(ctx, token)
+	if err != nil {
+		return fmt.Errorf("userinfo: %w", err)
+	}
+	return a.print(info)
+}
+
+// runUser 处理 user 子命令
+func (a *app) runUser(ctx context.Context, args []string) error {
+	if len(args) == 0 || args[0] != "get" {
+		fmt.Fprintln(a.stderr, "用法: goauthctl user get -sub <sub> [token]")
+		return errUsage
+	}
+
+	flags := a.newFlagSet("user get", "user get -sub <sub> [token]")
+	sub := flags.String("sub", "", "用户唯一标识（必填）")
+	if err := parseFlags(flags, args[1:]); err != nil {
+		return err
+	}
+	if *sub == "" {
+		flags.Usage()
+		return errUsage
+	}
+
+	s, err := a.openSession()
+	if err != nil {
+		return err
+	}
+	token, _, err := a.tokenArg(ctx, s, flags.Args(), false)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.client.GetUser(ctx, token, *sub)
+	if err != nil {
+		return fmt.Errorf("get user: %w", err)
+	}
+	return a.print(user)
+}
@@ -0,0 +1,295 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+)
+
+// defaultProfileName 是未指定配置档时使用的名称
+const defaultProfileName = "default"
+
+// defaultRedirectURI 是 login 使用的 loopback 回调地址，端口在登录时动态分配（需在 goauth 中注册）
+const defaultRedirectURI = "http://127.0.0.1/callback"
+
+// profileNamePattern 限制配置档名称的字符，名称会作为令牌文件名使用，不能包含路径分隔符或 ".."
+var profileNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// profile 是一个 goauth 部署与客户端的连接配置
+type profile struct {
+	FrontendBaseURL    string `json:"frontend_base_url"`
+	BackendBaseURL     string `json:"backend_base_url"`
+	ClientID           string `json:"client_id"`
+	ClientSecret       string `json:"client_secret,omitempty"`
+	RedirectURI        string `json:"redirect_uri,omitempty"`
+	Issuer             string `json:"issuer,omitempty"`
+	Scope              string `json:"scope,omitempty"`
+	AccessTokenSecret  string `json:"access_token_secret,omitempty"`
+	RefreshTokenSecret string `json:"refresh_token_secret,omitempty"`
+}
+
+// configFile 是配置文件内容
+type configFile struct {
+	CurrentProfile string              `json:"current_profile,omitempty"`
+	Profiles       map[string]*profile `json:"profiles"`
+}
+
+// resolveConfigPath 返回配置文件路径：-config 参数优先，否则为 <用户配置目录>/goauthctl/config.json
+func (a *app) resolveConfigPath() (string, error) {
+	if a.configPath != "" {
+		return a.configPath, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("locate user config dir: %w", err)
+	}
+	return filepath.Join(dir, "goauthctl", "config.json"), nil
+}
+
+// tokenPath 返回配置档的令牌文件路径，与配置文件位于同一目录下的 tokens 子目录
+func (a *app) tokenPath(name string) (string, error) {
+	if err := validateProfileName(name); err != nil {
+		return "", err
+	}
+	configPath, err := a.resolveConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(configPath), "tokens", name+".json"), nil
+}
+
+// validateProfileName 校验配置档名称只包含字母、数字、下划线与连字符
+func validateProfileName(name string) error {
+	if !profileNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q: only letters, digits, '_' and '-' are allowed", name)
+	}
+	return nil
+}
+
+// loadConfig 读取配置文件，文件不存在时返回空配置
+func (a *app) loadConfig() (*configFile, error) {
+	path, err := a.resolveConfigPath()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &configFile{Profiles: map[string]*profile{}}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read config: %w", err)
+	}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("parse config %s: %w", path, err)
+	}
+	if cfg.Profiles == nil {
+		cfg.Profiles = map[string]*profile{}
+	}
+	return cfg, nil
+}
+
+// saveConfig 写入配置文件（包含客户端密钥，权限 0600）
+func (a *app) saveConfig(cfg *configFile) error {
+	path, err := a.resolveConfigPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("create config dir: %w", err)
+	}
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode config: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("write config: %w", err)
+	}
+	return nil
+}
+
+// currentProfileName 返回本次使用的配置档名称：-profile 参数优先，其次为 current_profile
+func (a *app) currentProfileName(cfg *configFile) string {
+	if a.profileName != "" {
+		return a.profileName
+	}
+	if cfg.CurrentProfile != "" {
+		return cfg.CurrentProfile
+	}
+	return defaultProfileName
+}
+
+// loadProfile 读取本次使用的配置档
+func (a *app) loadProfile() (string, *profile, error) {
+	cfg, err := a.loadConfig()
+	if err != nil {
+		return "", nil, err
+	}
+	name := a.currentProfileName(cfg)
+	p, ok := cfg.Profiles[name]
+	if !ok {
+		return "", nil, fmt.Errorf("profile %s not found, create it with: goauthctl -profile %s profile set ...", name, name)
+	}
+	return name, p, nil
+}
+
+// runProfile 处理 profile 子命令
+func (a *app) runProfile(args []string) error {
+	if len(args) == 0 {
+		fmt.Fprintln(a.stderr, "用法: goauthctl profile set|list|show|use")
+		return errUsage
+	}
+
+	switch args[0] {
+	case "set":
+		return a.runProfileSet(args[1:])
+	case "list":
+		return a.runProfileList()
+	case "show":
+		return a.runProfileShow()
+	case "use":
+		return a.runProfileUse(args[1:])
+	}
+	fmt.Fprintf(a.stderr, "未知命令: profile %s\n", args[0])
+	return errUsage
+}
+
+// runProfileSet 创建或更新配置档，只覆盖显式传入的字段
+func (a *app) runProfileSet(args []string) error {
+	flags := a.newFlagSet("profile set", "profile set [-frontend url] [-backend url] [-client-id id] ...")
+	frontend := flags.String("frontend", "", "goauth 前端地址，例如 http://localhost:5173")
+	backend := flags.String("backend", "", "goauth 后端地址，例如 http://localhost:9000")
+	clientID := flags.String("client-id", "", "客户端 ID")
+	clientSecret := flags.String("client-secret", "", "客户端密钥（公开客户端留空）")
+	redirectURI := flags.String("redirect-uri", "", "登录回调地址（默认 "+defaultRedirectURI+"）")
+	issuer := flags.String("issuer", "", "授权服务器标识")
+	scope := flags.String("scope", "", "login 默认申请的 scope")
+	accessSecret := flags.String("access-token-secret", "", "访问令牌签名密钥（jwt verify 使用）")
+	refreshSecret := flags.String("refresh-token-secret", "", "刷新令牌签名密钥（jwt verify 使用）")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	cfg, err := a.loadConfig()
+	if err != nil {
+		return err
+	}
+	name := a.currentProfileName(cfg)
+	if err := validateProfileName(name); err != nil {
+		return err
+	}
+	p, ok := cfg.Profiles[name]
+	if !ok {
+		p = &profile{}
+		cfg.Profiles[name] = p
+	}
+
+	flags.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "frontend":
+			p.FrontendBaseURL = *frontend
+		case "backend":
+			p.BackendBaseURL = *backend
+		case "client-id":
+			p.ClientID = *clientID
+		case "client-secret":
+			p.ClientSecret = *clientSecret
+		case "redirect-uri":
+			p.RedirectURI = *redirectURI
+		case "issuer":
+			p.Issuer = *issuer
+		case "scope":
+			p.Scope = *scope
+		case "access-token-secret":
+			p.AccessTokenSecret = *accessSecret
+		case "refresh-token-secret":
+			p.RefreshTokenSecret = *refreshSecret
+		}
+	})
+	if cfg.CurrentProfile == "" {
+		cfg.CurrentProfile = name
+	}
+
+	if err := a.saveConfig(cfg); err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stderr, "已保存配置档 %s\n", name)
+	return nil
+}
+
+// runProfileList 列出全部配置档
+func (a *app) runProfileList() error {
+	cfg, err := a.loadConfig()
+	if err != nil {
+		return err
+	}
+	current := a.currentProfileName(cfg)
+
+	names := make([]string, 0, len(cfg.Profiles))
+	for name := range cfg.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	rows := make([]map[string]any, 0, len(names))
+	for _, name := range names {
+		rows = append(rows, map[string]any{
+			"name":     name,
+			"current":  name == current,
+			"backend":  cfg.Profiles[name].BackendBaseURL,
+			"clientId": cfg.Profiles[name].ClientID,
+		})
+	}
+	return a.printRows(rows, []string{"name", "current", "backend", "clientId"})
+}
+
+// runProfileShow 显示当前配置档（密钥脱敏）
+func (a *app) runProfileShow() error {
+	name, p, err := a.loadProfile()
+	if err != nil {
+		return err
+	}
+	shown := *p
+	shown.ClientSecret = mask(shown.ClientSecret)
+	shown.AccessTokenSecret = mask(shown.AccessTokenSecret)
+	shown.RefreshTokenSecret = mask(shown.RefreshTokenSecret)
+	return a.print(map[string]any{"name": name, "profile": shown})
+}
+
+// runProfileUse 切换默认配置档
+func (a *app) runProfileUse(args []string) error {
+	if len(args) != 1 {
+		fmt.Fprintln(a.stderr, "用法: goauthctl profile use <name>")
+		return errUsage
+	}
+	if err := validateProfileName(args[0]); err != nil {
+		return err
+	}
+	cfg, err := a.loadConfig()
+	if err != nil {
+		return err
+	}
+	if _, ok := cfg.Profiles[args[0]]; !ok {
+		return fmt.Errorf("profile %s not found", args[0])
+	}
+	cfg.CurrentProfile = args[0]
+	return a.saveConfig(cfg)
+}
+
+// mask 脱敏显示密钥，只保留前 4 个字符
+func mask(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	if len(secret) <= 4 {
+		return "****"
+	}
+	return secret[:4] + "****"
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+)
+
+// ============================================================================
+// goauthctl - goauthsdk 命令行工具，用于登录、获取/刷新/内省/撤销令牌与查询用户
+// ============================================================================
+
+// errUsage 表示命令参数错误，已输出用法说明
+var errUsage = errors.New("usage error")
+
+// app 是一次命令执行的上下文
+type app struct {
+	configPath  string
+	profileName string
+	output      string
+	stdout      io.Writer
+	stderr      io.Writer
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := run(ctx, os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		if !errors.Is(err, errUsage) {
+			fmt.Fprintf(os.Stderr, "goauthctl: %v\n", err)
+		}
+		os.Exit(1)
+	}
+}
+
+// run 解析全局参数并分发子命令
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	a := &app{stdout: stdout, stderr: stderr}
+
+	flags := flag.NewFlagSet("goauthctl", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.StringVar(&a.configPath, "config", os.Getenv("GOAUTHCTL_CONFIG"), "配置文件路径（默认 <用户配置目录>/goauthctl/config.json）")
+	flags.StringVar(&a.profileName, "profile", os.Getenv("GOAUTHCTL_PROFILE"), "使用的配置档（默认为配置文件中的 current_profile）")
+	flags.StringVar(&a.output, "o", "table", "输出格式：table 或 json")
+	flags.Usage = func() { printUsage(stderr, flags) }
+	if err := flags.Parse(args); err != nil {
+		return errUsage
+	}
+	if a.output != "table" && a.output != "json" {
+		return fmt.Errorf("unsupported output format: %s", a.output)
+	}
+
+	rest := flags.Args()
+	if len(rest) == 0 {
+		flags.Usage()
+		return errUsage
+	}
+
+	switch rest[0] {
+	case "profile":
+		return a.runProfile(rest[1:])
+	case "login":
+		return a.runLogin(ctx, rest[1:])
+	case "logout":
+		return a.runLogout(ctx, rest[1:])
+	case "token":
+		return a.runToken(ctx, rest[1:])
+	case "introspect":
+		return a.runIntrospect(ctx, rest[1:])
+	case "revoke":
+		return a.runRevoke(ctx, rest[1:])
+	case "userinfo":
+		return a.runUserInfo(ctx, rest[1:])
+	case "user":
+		return a.runUser(ctx, rest[1:])
+	case "jwt":
+		return a.runJWT(rest[1:])
+	case "help", "-h", "--help":
+		flags.Usage()
+		return nil
+	}
+	fmt.Fprintf(stderr, "未知命令: %s\n\n", rest[0])
+	flags.Usage()
+	return errUsage
+}
+
+// printUsage 输出总体用法说明
+func printUsage(w io.Writer, flags *flag.FlagSet) {
+	fmt.Fprint(w, `用法: goauthctl [全局参数] <命令> [参数]
+
+命令:
+  profile set|list|show|use         管理配置档
+  login                             通过浏览器登录（loopback 回调 + PKCE），令牌保存到本地
+  logout                            撤销并删除本地保存的令牌
+  token client-credentials          客户端凭证模式获取令牌
+  token refresh                     使用保存的刷新令牌刷新访问令牌
+  token show                        显示本地保存的令牌
+  introspect [token]                内省令牌（默认使用保存的访问令牌）
+  revoke [token]                    撤销令牌（默认撤销保存的刷新令牌）
+  userinfo [token]                  获取当前用户信息
+  user get -sub <sub>               获取用户详情
+  jwt decode <token>                解码 JWT（不校验签名）
+  jwt verify [-type access|refresh] <token>
+                                    使用配置档中的密钥离线验签
+
+全局参数:
+`)
+	flags.PrintDefaults()
+}
+
+// newFlagSet 创建子命令参数集合，错误与用法输出到 stderr
+func (a *app) newFlagSet(name, usage string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(a.stderr)
+	flags.Usage = func() {
+		fmt.Fprintf(a.stderr, "用法: goauthctl %s\n", usage)
+		flags.PrintDefaults()
+	}
+	return flags
+}
+
+// parseFlags 解析子命令参数，失败时返回 errUsage
+func parseFlags(flags *flag.FlagSet, args []string) error {
+	if err := flags.Parse(args); err != nil {
+		return errUsage
+	}
+	return nil
+}
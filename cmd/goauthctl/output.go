@@ -0,0 +1,105 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// print 按 -o 指定的格式输出 v
+// table 格式将 v 展开为「字段 值」两列，嵌套对象以 a.b 形式展开
+func (a *app) print(v any) error {
+	if a.output == "json" {
+		return a.printJSON(v)
+	}
+
+	fields, err := flatten(v)
+	if err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	w := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)
+	for _, key := range keys {
+		fmt.Fprintf(w, "%s\t%s\n", key, fields[key])
+	}
+	return w.Flush()
+}
+
+// printRows 按 -o 指定的格式输出多行记录，table 格式按 columns 的顺序输出列
+func (a *app) printRows(rows []map[string]any, columns []string) error {
+	if a.output == "json" {
+		return a.printJSON(rows)
+	}
+
+	w := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.ToUpper(strings.Join(columns, "\t")))
+	for _, row := range rows {
+		values := make([]string, len(columns))
+		for i, column := range columns {
+			values[i] = formatValue(row[column])
+		}
+		fmt.Fprintln(w, strings.Join(values, "\t"))
+	}
+	return w.Flush()
+}
+
+// printJSON 以缩进 JSON 输出 v
+func (a *app) printJSON(v any) error {
+	enc := json.NewEncoder(a.stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// flatten 将 v 经 JSON 编码后展开为「路径 → 值」
+func flatten(v any) (map[string]string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("encode output: %w", err)
+	}
+	var decoded any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, fmt.Errorf("encode output: %w", err)
+	}
+
+	fields := map[string]string{}
+	var walk func(prefix string, value any)
+	walk = func(prefix string, value any) {
+		object, ok := value.(map[string]any)
+		if !ok || len(object) == 0 {
+			fields[prefix] = formatValue(value)
+			return
+		}
+		for key, child := range object {
+			if prefix != "" {
+				key = prefix + "." + key
+			}
+			walk(key, child)
+		}
+	}
+	walk("", decoded)
+	return fields, nil
+}
+
+// formatValue 将 JSON 值格式化为单元格文本
+func formatValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []any, map[string]any:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,99 @@
+// goauthsdk-conformance 对 goauth 部署执行端到端一致性检查，并输出通过/失败报告
+//
+// 用法:
+//
+//	goauthsdk-conformance -backend http://localhost:9000 -client-id 1 -client-secret xxx
+//	goauthsdk-conformance -fake    # 对进程内模拟服务（goauthtest）执行检查，验证检查本身
+//
+// 存在失败的检查时退出码为 1
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/3086953492/goauthsdk/conformance"
+	"github.com/3086953492/goauthsdk/goauthtest"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+// run 解析参数并执行检查，返回进程退出码
+func run(args []string) int {
+	var (
+		cfg  conformance.Config
+		fake bool
+	)
+	flags := flag.NewFlagSet("goauthsdk-conformance", flag.ContinueOnError)
+	flags.StringVar(&cfg.FrontendBaseURL, "frontend", envOr("GOAUTHSDK_FRONTEND_BASE_URL", conformance.DefaultFrontendBaseURL), "OAuth 前端站点地址（环境变量 GOAUTHSDK_FRONTEND_BASE_URL）")
+	flags.StringVar(&cfg.BackendBaseURL, "backend", envOr("GOAUTHSDK_BACKEND_BASE_URL", conformance.DefaultBackendBaseURL), "OAuth 后端服务地址（环境变量 GOAUTHSDK_BACKEND_BASE_URL）")
+	flags.StringVar(&cfg.ClientID, "client-id", envOr("GOAUTHSDK_CLIENT_ID", "1"), "客户端 ID（环境变量 GOAUTHSDK_CLIENT_ID）")
+	flags.StringVar(&cfg.ClientSecret, "client-secret", os.Getenv("GOAUTHSDK_CLIENT_SECRET"), "客户端密钥（环境变量 GOAUTHSDK_CLIENT_SECRET）")
+	flags.StringVar(&cfg.RedirectURI, "redirect-uri", envOr("GOAUTHSDK_REDIRECT_URI", "http://localhost:7000/callback"), "客户端注册的回调地址（环境变量 GOAUTHSDK_REDIRECT_URI）")
+	flags.StringVar(&cfg.AccessTokenSecret, "access-token-secret", os.Getenv("GOAUTHSDK_ACCESS_TOKEN_SECRET"), "访问令牌签名密钥，配置后执行离线验签检查（环境变量 GOAUTHSDK_ACCESS_TOKEN_SECRET）")
+	flags.StringVar(&cfg.RefreshTokenSecret, "refresh-token-secret", os.Getenv("GOAUTHSDK_REFRESH_TOKEN_SECRET"), "刷新令牌签名密钥（环境变量 GOAUTHSDK_REFRESH_TOKEN_SECRET）")
+	flags.StringVar(&cfg.Scope, "scope", conformance.DefaultScope, "申请的 scope")
+	flags.StringVar(&cfg.RefreshToken, "refresh-token", os.Getenv("GOAUTHSDK_REFRESH_TOKEN"), "预先获取的刷新令牌，用于用户令牌相关检查（环境变量 GOAUTHSDK_REFRESH_TOKEN）")
+	flags.StringVar(&cfg.Subject, "subject", "", "已存在的用户标识，用于用户详情检查（默认使用用户信息接口返回的 sub）")
+	flags.DurationVar(&cfg.CheckTimeout, "timeout", conformance.DefaultCheckTimeout, "单项检查超时时间")
+	flags.BoolVar(&fake, "fake", false, "对进程内模拟服务执行检查，忽略地址与客户端参数")
+
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	if fake {
+		srv := goauthtest.NewServer()
+		defer srv.Close()
+		cfg = fakeConfig(srv, cfg.Scope, cfg.CheckTimeout)
+	}
+
+	start := time.Now()
+	report, err := conformance.Run(context.Background(), cfg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "goauthsdk-conformance:", err)
+		return 2
+	}
+
+	fmt.Printf("goauth conformance: %s (client %s)\n\n", cfg.BackendBaseURL, cfg.ClientID)
+	report.Print(os.Stdout)
+	fmt.Printf("total %v\n", time.Since(start).Round(time.Millisecond))
+
+	if report.Failed() > 0 {
+		return 1
+	}
+	return 0
+}
+
+// fakeConfig 返回对接模拟服务的配置，授权由 Server.Authorize 自动完成
+func fakeConfig(srv *goauthtest.Server, scope string, timeout time.Duration) conformance.Config {
+	return conformance.Config{
+		FrontendBaseURL:    srv.URL,
+		BackendBaseURL:     srv.URL,
+		ClientID:           goauthtest.DefaultClientID,
+		ClientSecret:       goauthtest.DefaultClientSecret,
+		RedirectURI:        goauthtest.DefaultRedirectURI,
+		AccessTokenSecret:  srv.AccessTokenSecret(),
+		RefreshTokenSecret: srv.RefreshTokenSecret(),
+		Scope:              scope,
+		Login:              srv.Authorize,
+		CheckTimeout:       timeout,
+	}
+}
+
+// envOr 返回环境变量的值，未设置或为空时返回默认值
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
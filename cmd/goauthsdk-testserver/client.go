@@ -7,22 +7,22 @@ import (
 // newTestClient 创建测试客户端
 func newTestClient() (*goauthsdk.Client, error) {
 	return goauthsdk.NewClient(
-		testFrontendBaseURL,
-		testBackendBaseURL,
-		testClientID,
-		testClientSecret,
-		testRedirectURI,
+		cfg.FrontendBaseURL,
+		cfg.BackendBaseURL,
+		cfg.ClientID,
+		cfg.ClientSecret,
+		cfg.RedirectURI,
 	)
 }
 
 // newTestClientWithJWT 创建支持离线验签的测试客户端
 func newTestClientWithJWT() (*goauthsdk.Client, error) {
 	return goauthsdk.NewClient(
-		testFrontendBaseURL,
-		testBackendBaseURL,
-		testClientID,
-		testClientSecret,
-		testRedirectURI,
-		goauthsdk.WithJWTSecrets(testAccessTokenSecret, testRefreshTokenSecret),
+		cfg.FrontendBaseURL,
+		cfg.BackendBaseURL,
+		cfg.ClientID,
+		cfg.ClientSecret,
+		cfg.RedirectURI,
+		goauthsdk.WithJWTSecrets(cfg.AccessTokenSecret, cfg.RefreshTokenSecret),
 	)
 }
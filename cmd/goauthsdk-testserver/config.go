@@ -1,27 +1,77 @@
 package main
 
+import (
+	"flag"
+	"os"
+)
+
+// 默认配置，可通过命令行参数或环境变量覆盖
 const (
-	// testFrontendBaseURL OAuth 前端站点地址
-	testFrontendBaseURL = "http://localhost:5173"
+	// defaultFrontendBaseURL OAuth 前端站点地址
+	defaultFrontendBaseURL = "http://localhost:5173"
 
-	// testBackendBaseURL OAuth 后端服务地址
-	testBackendBaseURL = "http://localhost:9000"
+	// defaultBackendBaseURL OAuth 后端服务地址
+	defaultBackendBaseURL = "http://localhost:9000"
 
-	// testClientID OAuth 客户端 ID
-	testClientID = "1"
+	// defaultClientID OAuth 客户端 ID
+	defaultClientID = "1"
 
-	// testClientSecret OAuth 客户端密钥
-	testClientSecret = "mC9dvSBXPIIDLWP2MSauuxybZmICfNpq"
+	// defaultClientSecret OAuth 客户端密钥
+	defaultClientSecret = "mC9dvSBXPIIDLWP2MSauuxybZmICfNpq"
 
-	// testRedirectURI OAuth 回调地址，需与客户端注册的回调地址一致
-	testRedirectURI = "http://localhost:7000/callback"
+	// defaultRedirectURI OAuth 回调地址，需与客户端注册的回调地址一致
+	defaultRedirectURI = "http://localhost:7000/callback"
 
-	// testAccessTokenSecret 访问令牌签名密钥，用于离线验证访问令牌（需与 goauth 服务端配置一致）
-	testAccessTokenSecret = "GO4ymlqBMkucpQ60roh17ZADPcY8outx"
+	// defaultAccessTokenSecret 访问令牌签名密钥，用于离线验证访问令牌（需与 goauth 服务端配置一致）
+	defaultAccessTokenSecret = "GO4ymlqBMkucpQ60roh17ZADPcY8outx"
 
-	// testRefreshTokenSecret 刷新令牌签名密钥，用于离线验证刷新令牌（需与 goauth 服务端配置一致）
-	testRefreshTokenSecret = "tnwBPejxaajp3m1AzLMAs9viS4GLGoLj"
+	// defaultRefreshTokenSecret 刷新令牌签名密钥，用于离线验证刷新令牌（需与 goauth 服务端配置一致）
+	defaultRefreshTokenSecret = "tnwBPejxaajp3m1AzLMAs9viS4GLGoLj"
 
-	// serverAddr 测试服务监听地址
-	serverAddr = ":7000"
+	// defaultServerAddr 测试服务监听地址
+	defaultServerAddr = ":7000"
 )
+
+// testConfig 测试服务配置
+type testConfig struct {
+	FrontendBaseURL    string
+	BackendBaseURL     string
+	ClientID           string
+	ClientSecret       string
+	RedirectURI        string
+	AccessTokenSecret  string
+	RefreshTokenSecret string
+	ServerAddr         string
+	Fake               bool // 是否使用进程内模拟服务（goauthtest）代替真实的 goauth 服务
+}
+
+// cfg 当前生效的配置，启动时由 loadConfig 填充
+var cfg testConfig
+
+// loadConfig 解析命令行参数；未指定的参数依次取环境变量、默认值
+func loadConfig(args []string) (testConfig, error) {
+	var c testConfig
+	flags := flag.NewFlagSet("goauthsdk-testserver", flag.ContinueOnError)
+	flags.StringVar(&c.FrontendBaseURL, "frontend", envOr("GOAUTHSDK_FRONTEND_BASE_URL", defaultFrontendBaseURL), "OAuth 前端站点地址（环境变量 GOAUTHSDK_FRONTEND_BASE_URL）")
+	flags.StringVar(&c.BackendBaseURL, "backend", envOr("GOAUTHSDK_BACKEND_BASE_URL", defaultBackendBaseURL), "OAuth 后端服务地址（环境变量 GOAUTHSDK_BACKEND_BASE_URL）")
+	flags.StringVar(&c.ClientID, "client-id", envOr("GOAUTHSDK_CLIENT_ID", defaultClientID), "OAuth 客户端 ID（环境变量 GOAUTHSDK_CLIENT_ID）")
+	flags.StringVar(&c.ClientSecret, "client-secret", envOr("GOAUTHSDK_CLIENT_SECRET", defaultClientSecret), "OAuth 客户端密钥（环境变量 GOAUTHSDK_CLIENT_SECRET）")
+	flags.StringVar(&c.RedirectURI, "redirect-uri", envOr("GOAUTHSDK_REDIRECT_URI", defaultRedirectURI), "OAuth 回调地址（环境变量 GOAUTHSDK_REDIRECT_URI）")
+	flags.StringVar(&c.AccessTokenSecret, "access-token-secret", envOr("GOAUTHSDK_ACCESS_TOKEN_SECRET", defaultAccessTokenSecret), "访问令牌签名密钥（环境变量 GOAUTHSDK_ACCESS_TOKEN_SECRET）")
+	flags.StringVar(&c.RefreshTokenSecret, "refresh-token-secret", envOr("GOAUTHSDK_REFRESH_TOKEN_SECRET", defaultRefreshTokenSecret), "刷新令牌签名密钥（环境变量 GOAUTHSDK_REFRESH_TOKEN_SECRET）")
+	flags.StringVar(&c.ServerAddr, "addr", envOr("GOAUTHSDK_TESTSERVER_ADDR", defaultServerAddr), "测试服务监听地址（环境变量 GOAUTHSDK_TESTSERVER_ADDR）")
+	flags.BoolVar(&c.Fake, "fake", os.Getenv("GOAUTHSDK_TESTSERVER_FAKE") == "true", "使用进程内模拟服务，无需启动 goauth（环境变量 GOAUTHSDK_TESTSERVER_FAKE=true）")
+
+	if err := flags.Parse(args); err != nil {
+		return testConfig{}, err
+	}
+	return c, nil
+}
+
+// envOr 返回环境变量的值，未设置或为空时返回默认值
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
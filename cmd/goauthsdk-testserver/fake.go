@@ -0,0 +1,18 @@
+package main
+
+import (
+	"github.com/3086953492/goauthsdk/goauthtest"
+)
+
+// startFakeServer 启动进程内模拟服务（goauthtest），并将前端、后端地址指向它
+// 模拟服务使用当前配置的客户端、回调地址与 JWT 密钥，授权页以默认用户自动同意授权，
+// 因此 /auth → /callback → /refresh → /revoke 全流程无需任何外部服务
+func startFakeServer(c *testConfig) *goauthtest.Server {
+	srv := goauthtest.NewServer(
+		goauthtest.WithClient(c.ClientID, c.ClientSecret, c.RedirectURI),
+		goauthtest.WithJWTSecrets(c.AccessTokenSecret, c.RefreshTokenSecret),
+	)
+	c.FrontendBaseURL = srv.URL
+	c.BackendBaseURL = srv.URL
+	return srv
+}
@@ -2,12 +2,17 @@ package main
 
 import (
 	"context"
+	"errors"
 	"log"
 	"net/http"
 
+	"github.com/3086953492/goauthsdk"
 	"github.com/gin-gonic/gin"
 )
 
+// stateCookieName 保存授权 state 的 Cookie 名称，回调时用于校验
+const stateCookieName = "goauthsdk_state"
+
 // handleAuth 处理授权发起请求
 func handleAuth(c *gin.Context) {
 	// 创建客户端
@@ -34,6 +39,9 @@ func handleAuth(c *gin.Context) {
 		return
 	}
 
+	// 保存 state，回调时校验（有效期 10 分钟）
+	c.SetCookie(stateCookieName, state, 600, "/", "", false, true)
+
 	log.Printf("发起授权请求: scope=%s, state=%s", scope, state)
 	log.Printf("重定向到: %s", authURL)
 
@@ -43,20 +51,20 @@ func handleAuth(c *gin.Context) {
 
 // handleCallback 处理授权回调请求
 func handleCallback(c *gin.Context) {
-	// 读取授权码
-	code := c.Query("code")
 	state := c.Query("state")
+	log.Printf("收到回调请求: state=%s", state)
 
-	log.Printf("收到回调请求: code=%s, state=%s", code, state)
-
-	if code == "" {
+	// 读取发起授权时保存的 state
+	expectedState, err := c.Cookie(stateCookieName)
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error":  "缺少授权码",
-			"detail": "未收到 code 参数，授权可能失败",
+			"error":  "缺少 state Cookie",
+			"detail": "请先访问 /auth 发起授权",
 			"state":  state,
 		})
 		return
 	}
+	c.SetCookie(stateCookieName, "", -1, "/", "", false, true)
 
 	// 创建客户端
 	client, err := newTestClient()
@@ -68,15 +76,40 @@ func handleCallback(c *gin.Context) {
 		return
 	}
 
+	// 解析并校验回调参数（state、iss、error）
+	authResp, err := client.ParseAuthorizationCallback(c.Request, expectedState)
+	if err != nil {
+		log.Printf("解析回调失败: %v", err)
+
+		// 授权服务器返回的错误（如用户拒绝授权）
+		var authErr *goauthsdk.AuthorizationError
+		if errors.As(err, &authErr) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":             "授权失败",
+				"error_code":        authErr.Code,
+				"error_description": authErr.Description,
+				"access_denied":     errors.Is(err, goauthsdk.ErrAccessDenied),
+				"state":             state,
+			})
+			return
+		}
+
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":  "回调参数校验失败",
+			"detail": err.Error(),
+			"state":  state,
+		})
+		return
+	}
+
 	// 交换访问令牌
 	log.Printf("开始交换访问令牌...")
-	token, err := client.ExchangeToken(context.Background(), code)
+	token, err := client.ExchangeToken(context.Background(), authResp.Code)
 	if err != nil {
 		log.Printf("交换令牌失败: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":  "交换访问令牌失败",
 			"detail": err.Error(),
-			"code":   code,
 			"state":  state,
 		})
 		return
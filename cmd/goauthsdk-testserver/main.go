@@ -1,7 +1,13 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"log"
+	"net"
+	"os"
+
+	"github.com/3086953492/goauthsdk/goauthtest"
 )
 
 // ============================================================================
@@ -9,15 +15,43 @@ import (
 // ============================================================================
 
 func main() {
-	log.Printf("启动 goauthsdk 测试服务于 %s", serverAddr)
+	c, err := loadConfig(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		os.Exit(2)
+	}
+
+	if c.Fake {
+		fake := startFakeServer(&c)
+		defer fake.Close()
+		log.Printf("已启动模拟授权服务于 %s（授权页自动同意，登录用户 %s）", fake.URL, goauthtest.DefaultUser.Subject)
+	}
+	cfg = c
+
+	log.Printf("启动 goauthsdk 测试服务于 %s", cfg.ServerAddr)
 	log.Printf("配置信息:")
-	log.Printf("  - 前端地址: %s", testFrontendBaseURL)
-	log.Printf("  - 后端地址: %s", testBackendBaseURL)
-	log.Printf("  - 客户端ID: %s", testClientID)
-	log.Printf("  - 回调地址: %s", testRedirectURI)
-	log.Printf("\n访问 http://localhost%s/ 查看使用说明\n", serverAddr)
+	log.Printf("  - 前端地址: %s", cfg.FrontendBaseURL)
+	log.Printf("  - 后端地址: %s", cfg.BackendBaseURL)
+	log.Printf("  - 客户端ID: %s", cfg.ClientID)
+	log.Printf("  - 回调地址: %s", cfg.RedirectURI)
+	log.Printf("\n访问 %s 查看使用说明\n", visitURL(cfg.ServerAddr))
 
-	if err := startServer(serverAddr); err != nil {
+	if err := startServer(cfg.ServerAddr); err != nil {
 		log.Fatal(err)
 	}
 }
+
+// visitURL 返回浏览器访问监听地址 addr 使用的 URL
+// 未指定主机或监听全部地址（0.0.0.0、::）时使用 localhost；IPv6 地址加方括号
+func visitURL(addr string) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "http://" + addr + "/"
+	}
+	if ip := net.ParseIP(host); host == "" || (ip != nil && ip.IsUnspecified()) {
+		host = "localhost"
+	}
+	return "http://" + net.JoinHostPort(host, port) + "/"
+}
@@ -15,15 +15,16 @@ func startServer(addr string) error {
 		c.JSON(http.StatusOK, gin.H{
 			"message": "goauthsdk 手工测试服务",
 			"config": gin.H{
-				"frontend_base_url": testFrontendBaseURL,
-				"backend_base_url":  testBackendBaseURL,
-				"client_id":         testClientID,
-				"redirect_uri":      testRedirectURI,
+				"frontend_base_url": cfg.FrontendBaseURL,
+				"backend_base_url":  cfg.BackendBaseURL,
+				"client_id":         cfg.ClientID,
+				"redirect_uri":      cfg.RedirectURI,
+				"fake":              cfg.Fake,
 			},
 			"routes": gin.H{
 				"GET /":                   "本说明页",
 				"GET /auth":               "发起 OAuth 授权（可选参数: ?scope=read&state=test）",
-				"GET /callback":           "OAuth 回调地址（自动接收 code 并交换 token）",
+				"GET /callback":           "OAuth 回调地址（校验 state 后自动接收 code 并交换 token）",
 				"GET /client_credentials": "客户端凭证模式获取令牌（可选参数: ?scope=api）",
 				"GET /introspect":         "内省令牌（必需: ?token=xxx，可选: &token_type_hint=access_token|refresh_token）",
 				"GET /refresh":            "刷新访问令牌（必需参数: ?refresh_token=xxx）",
@@ -37,6 +38,7 @@ func startServer(addr string) error {
 				"1. 访问 /auth 发起授权",
 				"2. 在 OAuth 授权页面确认授权",
 				"3. 自动跳转回 /callback 并显示访问令牌",
+				"使用 -fake 启动时授权页自动同意授权，无需启动 goauth 前后端",
 			},
 		})
 	})
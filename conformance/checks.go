@@ -0,0 +1,557 @@
+package conformance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/3086953492/goauthsdk"
+	"github.com/3086953492/goauthsdk/internal/cryptox"
+)
+
+// suite 保存一次检查运行的配置与各项检查之间共享的状态
+type suite struct {
+	cfg    Config
+	client *goauthsdk.Client
+
+	code        string                   // 已使用的授权码，用于重复使用检查
+	user        *goauthsdk.TokenResponse // 用户令牌
+	clientToken string                   // 客户端凭证令牌（包含 Scope）
+	subject     string                   // 已知用户标识
+}
+
+// check 单项检查
+type check struct {
+	name string
+	fn   func(s *suite, ctx context.Context) error
+}
+
+// checks 全部检查，按顺序执行；撤销相关检查会使令牌失效，因此放在最后
+var checks = []check{
+	{"authorize/build_url", (*suite).checkBuildAuthorizationURL},
+	{"authorize/state_mismatch", (*suite).checkStateMismatch},
+	{"authorize/error_response", (*suite).checkAuthorizationError},
+	{"token/invalid_code", (*suite).checkInvalidCode},
+	{"token/authorization_code", (*suite).checkAuthorizationCode},
+	{"token/code_reuse", (*suite).checkCodeReuse},
+	{"token/refresh", (*suite).checkRefresh},
+	{"token/invalid_refresh_token", (*suite).checkInvalidRefreshToken},
+	{"token/client_credentials", (*suite).checkClientCredentials},
+	{"token/invalid_client", (*suite).checkInvalidClient},
+	{"token/exchange", (*suite).checkTokenExchange},
+	{"token/jwt_bearer_invalid_assertion", (*suite).checkInvalidJWTBearer},
+	{"jwt/offline_verify", (*suite).checkOfflineVerify},
+	{"introspect/active", (*suite).checkIntrospectActive},
+	{"introspect/invalid_token", (*suite).checkIntrospectInvalid},
+	{"userinfo", (*suite).checkUserInfo},
+	{"userinfo/invalid_token", (*suite).checkUserInfoInvalidToken},
+	{"users/get", (*suite).checkGetUser},
+	{"users/unknown_sub", (*suite).checkGetUnknownUser},
+	{"users/insufficient_scope", (*suite).checkGetUserInsufficientScope},
+	{"device/authorization", (*suite).checkDeviceAuthorization},
+	{"par/push", (*suite).checkPushedAuthorization},
+	{"logout/build_url", (*suite).checkBuildLogoutURL},
+	{"revoke/unknown_token", (*suite).checkRevokeUnknownToken},
+	{"revoke/client_token", (*suite).checkRevokeClientToken},
+	{"introspect/revoked_token", (*suite).checkIntrospectRevoked},
+	{"users/revoked_token", (*suite).checkGetUserRevokedToken},
+	{"logout", (*suite).checkLogout},
+	{"userinfo/revoked_token", (*suite).checkUserInfoRevokedToken},
+	{"token/refresh_revoked", (*suite).checkRefreshRevoked},
+}
+
+// ============================================================================
+// 授权
+// ============================================================================
+
+func (s *suite) checkBuildAuthorizationURL(_ context.Context) error {
+	authURL, err := s.client.BuildAuthorizationURL("conformance-state", s.cfg.Scope)
+	if err != nil {
+		return err
+	}
+	u, err := url.Parse(authURL)
+	if err != nil {
+		return fmt.Errorf("parse authorization url: %w", err)
+	}
+
+	q := u.Query()
+	want := map[string]string{
+		"response_type": "code",
+		"client_id":     s.cfg.ClientID,
+		"redirect_uri":  s.cfg.RedirectURI,
+		"scope":         s.cfg.Scope,
+		"state":         "conformance-state",
+	}
+	for key, value := range want {
+		if q.Get(key) != value {
+			return fmt.Errorf("%s = %q, want %q", key, q.Get(key), value)
+		}
+	}
+	return nil
+}
+
+func (s *suite) checkStateMismatch(_ context.Context) error {
+	_, err := s.client.ParseAuthorizationResponse(url.Values{"code": {"x"}, "state": {"other"}}, "expected")
+	if !errors.Is(err, goauthsdk.ErrStateMismatch) {
+		return fmt.Errorf("got %v, want ErrStateMismatch", err)
+	}
+	return nil
+}
+
+func (s *suite) checkAuthorizationError(_ context.Context) error {
+	values := url.Values{"error": {"access_denied"}, "state": {"expected"}}
+	_, err := s.client.ParseAuthorizationResponse(values, "expected")
+	if !errors.Is(err, goauthsdk.ErrAccessDenied) {
+		return fmt.Errorf("got %v, want ErrAccessDenied", err)
+	}
+	return nil
+}
+
+// ============================================================================
+// 令牌
+// ============================================================================
+
+func (s *suite) checkInvalidCode(ctx context.Context) error {
+	_, err := s.client.ExchangeToken(ctx, "conformance-invalid-code")
+	return expectAPIError(err, http.StatusBadRequest)
+}
+
+func (s *suite) checkAuthorizationCode(ctx context.Context) error {
+	if s.cfg.Login == nil {
+		return skipf("Login is not configured")
+	}
+
+	state, err := cryptox.RandomString(16)
+	if err != nil {
+		return err
+	}
+	pkce, err := goauthsdk.GeneratePKCE()
+	if err != nil {
+		return err
+	}
+	authURL, err := s.client.BuildAuthorizationURL(state, s.cfg.Scope, goauthsdk.WithPKCE(pkce))
+	if err != nil {
+		return err
+	}
+
+	values, err := s.cfg.Login(ctx, authURL)
+	if err != nil {
+		return fmt.Errorf("login: %w", err)
+	}
+	resp, err := s.client.ParseAuthorizationResponse(values, state)
+	if err != nil {
+		return err
+	}
+
+	token, err := s.client.ExchangeToken(ctx, resp.Code, goauthsdk.WithCodeVerifier(pkce.Verifier))
+	if err != nil {
+		return err
+	}
+	if err := validateTokenResponse(token); err != nil {
+		return err
+	}
+	s.code, s.user = resp.Code, token
+	return nil
+}
+
+func (s *suite) checkCodeReuse(ctx context.Context) error {
+	if s.code == "" {
+		return skipf("no authorization code")
+	}
+	_, err := s.client.ExchangeToken(ctx, s.code)
+	return expectAPIError(err, http.StatusBadRequest)
+}
+
+func (s *suite) checkRefresh(ctx context.Context) error {
+	refreshToken := s.cfg.RefreshToken
+	if s.user != nil {
+		refreshToken = s.user.RefreshToken.RefreshToken
+	}
+	if refreshToken == "" {
+		return skipf("no refresh token: configure Login or RefreshToken")
+	}
+
+	token, err := s.client.RefreshToken(ctx, refreshToken)
+	if err != nil {
+		return err
+	}
+	if err := validateTokenResponse(token); err != nil {
+		return err
+	}
+	s.user = token
+	return nil
+}
+
+func (s *suite) checkInvalidRefreshToken(ctx context.Context) error {
+	_, err := s.client.RefreshToken(ctx, "conformance-invalid-refresh-token")
+	return expectAPIError(err, http.StatusBadRequest)
+}
+
+func (s *suite) checkClientCredentials(ctx context.Context) error {
+	if s.cfg.ClientSecret == "" {
+		return skipf("ClientSecret is not configured")
+	}
+
+	token, err := s.client.ClientCredentialsToken(ctx, s.cfg.Scope)
+	if err != nil {
+		return err
+	}
+	if token.AccessToken == "" || token.ExpiresIn <= 0 {
+		return fmt.Errorf("incomplete response: access_token or expires_in is empty")
+	}
+	s.clientToken = token.AccessToken
+	return nil
+}
+
+func (s *suite) checkInvalidClient(ctx context.Context) error {
+	client, err := goauthsdk.NewClient(s.cfg.FrontendBaseURL, s.cfg.BackendBaseURL, s.cfg.ClientID,
+		"conformance-invalid-secret", s.cfg.RedirectURI, s.cfg.ClientOptions...)
+	if err != nil {
+		return err
+	}
+	_, err = client.ClientCredentialsToken(ctx, s.cfg.Scope)
+	return expectAPIError(err, http.StatusUnauthorized)
+}
+
+func (s *suite) checkTokenExchange(ctx context.Context) error {
+	if err := s.requireUser(); err != nil {
+		return err
+	}
+
+	resp, err := s.client.ExchangeTokenFor(ctx, goauthsdk.TokenExchangeRequest{
+		SubjectToken: s.user.AccessToken.AccessToken,
+	})
+	if unsupported(err) {
+		return skipf("token exchange is not supported: %v", err)
+	}
+	if err != nil {
+		return err
+	}
+	if resp.AccessToken == "" || resp.IssuedTokenType == "" {
+		return fmt.Errorf("incomplete response: access_token or issued_token_type is empty")
+	}
+	return nil
+}
+
+func (s *suite) checkInvalidJWTBearer(ctx context.Context) error {
+	_, err := s.client.JWTBearerToken(ctx, "conformance.invalid.assertion", s.cfg.Scope)
+	if unsupported(err) {
+		return skipf("jwt bearer grant is not supported: %v", err)
+	}
+	return expectAPIError(err, http.StatusBadRequest)
+}
+
+func (s *suite) checkOfflineVerify(_ context.Context) error {
+	if s.cfg.AccessTokenSecret == "" || s.cfg.RefreshTokenSecret == "" {
+		return skipf("AccessTokenSecret/RefreshTokenSecret are not configured")
+	}
+
+	tokens := 0
+	if s.clientToken != "" {
+		if err := s.client.ValidateToken(s.clientToken); err != nil {
+			return fmt.Errorf("validate client token: %w", err)
+		}
+		tokens++
+	}
+	if s.user != nil {
+		if _, err := s.client.ParseAccessToken(s.user.AccessToken.AccessToken); err != nil {
+			return fmt.Errorf("parse access token: %w", err)
+		}
+		if _, err := s.client.ParseRefreshToken(s.user.RefreshToken.RefreshToken); err != nil {
+			return fmt.Errorf("parse refresh token: %w", err)
+		}
+		if _, err := s.client.ParseAccessToken(s.user.RefreshToken.RefreshToken); err == nil {
+			return fmt.Errorf("refresh token accepted as access token")
+		}
+		tokens++
+	}
+	if tokens == 0 {
+		return skipf("no token to verify")
+	}
+	return nil
+}
+
+// ============================================================================
+// 内省与用户
+// ============================================================================
+
+func (s *suite) checkIntrospectActive(ctx context.Context) error {
+	token := s.clientToken
+	if s.user != nil {
+		token = s.user.AccessToken.AccessToken
+	}
+	if token == "" {
+		return skipf("no access token")
+	}
+
+	resp, err := s.client.IntrospectTokenWithHint(ctx, token, "access_token")
+	if err != nil {
+		return err
+	}
+	if !resp.Active {
+		return fmt.Errorf("active = false, want true")
+	}
+	if resp.ClientID != s.cfg.ClientID {
+		return fmt.Errorf("client_id = %q, want %q", resp.ClientID, s.cfg.ClientID)
+	}
+	return nil
+}
+
+func (s *suite) checkIntrospectInvalid(ctx context.Context) error {
+	resp, err := s.client.IntrospectToken(ctx, "conformance-invalid-token")
+	if err != nil {
+		return err
+	}
+	if resp.Active {
+		return fmt.Errorf("active = true, want false")
+	}
+	return nil
+}
+
+func (s *suite) checkUserInfo(ctx context.Context) error {
+	if err := s.requireUser(); err != nil {
+		return err
+	}
+
+	info, err := s.client.UserInfo(ctx, s.user.AccessToken.AccessToken)
+	if err != nil {
+		return err
+	}
+	if info.Sub == "" {
+		return fmt.Errorf("sub is empty")
+	}
+	if s.subject == "" {
+		s.subject = info.Sub
+	}
+	return nil
+}
+
+func (s *suite) checkUserInfoInvalidToken(ctx context.Context) error {
+	_, err := s.client.UserInfo(ctx, "conformance-invalid-token")
+	return expectAPIError(err, http.StatusUnauthorized)
+}
+
+func (s *suite) checkGetUser(ctx context.Context) error {
+	if err := s.requireClientToken(); err != nil {
+		return err
+	}
+	subject := s.cfg.Subject
+	if subject == "" {
+		subject = s.subject
+	}
+	if subject == "" {
+		return skipf("no known subject: configure Subject or Login")
+	}
+
+	user, err := s.client.GetUser(ctx, s.clientToken, subject)
+	if err != nil {
+		return err
+	}
+	if user.Subject != subject {
+		return fmt.Errorf("subject = %q, want %q", user.Subject, subject)
+	}
+	return nil
+}
+
+func (s *suite) checkGetUnknownUser(ctx context.Context) error {
+	if err := s.requireClientToken(); err != nil {
+		return err
+	}
+	_, err := s.client.GetUser(ctx, s.clientToken, "conformance-unknown-subject")
+	return expectAPIError(err, http.StatusNotFound)
+}
+
+func (s *suite) checkGetUserInsufficientScope(ctx context.Context) error {
+	if err := s.requireClientToken(); err != nil {
+		return err
+	}
+
+	// 不申请 scope 的客户端凭证令牌无权访问用户详情
+	token, err := s.client.ClientCredentialsToken(ctx, "")
+	if err != nil {
+		return err
+	}
+	_, err = s.client.GetUser(ctx, token.AccessToken, "conformance-unknown-subject")
+	return expectAPIError(err, http.StatusForbidden)
+}
+
+// ============================================================================
+// 设备授权、PAR 与登出
+// ============================================================================
+
+func (s *suite) checkDeviceAuthorization(ctx context.Context) error {
+	auth, err := s.client.RequestDeviceAuthorization(ctx, s.cfg.Scope)
+	if unsupported(err) {
+		return skipf("device authorization is not supported: %v", err)
+	}
+	if err != nil {
+		return err
+	}
+	if auth.DeviceCode == "" || auth.UserCode == "" || auth.VerificationURI == "" {
+		return fmt.Errorf("incomplete response: device_code, user_code or verification_uri is empty")
+	}
+
+	// 用户未批准时，轮询应一直等待（authorization_pending）直到超时
+	interval := time.Duration(auth.Interval) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	pollCtx, cancel := context.WithTimeout(ctx, 2*interval)
+	defer cancel()
+
+	if _, err := s.client.PollDeviceToken(pollCtx, auth); !errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("poll before approval: got %v, want authorization_pending until timeout", err)
+	}
+	return nil
+}
+
+func (s *suite) checkPushedAuthorization(ctx context.Context) error {
+	resp, err := s.client.PushAuthorizationRequest(ctx, "conformance-state", s.cfg.Scope)
+	if unsupported(err) {
+		return skipf("pushed authorization requests are not supported: %v", err)
+	}
+	if err != nil {
+		return err
+	}
+	if resp.RequestURI == "" {
+		return fmt.Errorf("request_uri is empty")
+	}
+	_, err = s.client.BuildPushedAuthorizationURL(resp.RequestURI)
+	return err
+}
+
+func (s *suite) checkBuildLogoutURL(_ context.Context) error {
+	logoutURL, err := s.client.BuildLogoutURL("", s.cfg.RedirectURI, "conformance-state")
+	if err != nil {
+		return err
+	}
+	u, err := url.Parse(logoutURL)
+	if err != nil {
+		return fmt.Errorf("parse logout url: %w", err)
+	}
+	if u.Query().Get("client_id") != s.cfg.ClientID {
+		return fmt.Errorf("client_id = %q, want %q", u.Query().Get("client_id"), s.cfg.ClientID)
+	}
+	return nil
+}
+
+// ============================================================================
+// 撤销（执行后令牌失效）
+// ============================================================================
+
+func (s *suite) checkRevokeUnknownToken(ctx context.Context) error {
+	// RFC 7009 2.2：撤销无效令牌同样返回成功
+	return s.client.RevokeToken(ctx, "conformance-unknown-token")
+}
+
+func (s *suite) checkRevokeClientToken(ctx context.Context) error {
+	if err := s.requireClientToken(); err != nil {
+		return err
+	}
+	return s.client.RevokeTokenWithHint(ctx, s.clientToken, "access_token")
+}
+
+func (s *suite) checkIntrospectRevoked(ctx context.Context) error {
+	if err := s.requireClientToken(); err != nil {
+		return err
+	}
+
+	resp, err := s.client.IntrospectToken(ctx, s.clientToken)
+	if err != nil {
+		return err
+	}
+	if resp.Active {
+		return fmt.Errorf("revoked token is still active")
+	}
+	return nil
+}
+
+func (s *suite) checkGetUserRevokedToken(ctx context.Context) error {
+	if err := s.requireClientToken(); err != nil {
+		return err
+	}
+	_, err := s.client.GetUser(ctx, s.clientToken, "conformance-unknown-subject")
+	return expectAPIError(err, http.StatusUnauthorized)
+}
+
+func (s *suite) checkLogout(ctx context.Context) error {
+	if err := s.requireUser(); err != nil {
+		return err
+	}
+	return s.client.Logout(ctx, s.user)
+}
+
+func (s *suite) checkUserInfoRevokedToken(ctx context.Context) error {
+	if err := s.requireUser(); err != nil {
+		return err
+	}
+	_, err := s.client.UserInfo(ctx, s.user.AccessToken.AccessToken)
+	return expectAPIError(err, http.StatusUnauthorized)
+}
+
+func (s *suite) checkRefreshRevoked(ctx context.Context) error {
+	if err := s.requireUser(); err != nil {
+		return err
+	}
+	_, err := s.client.RefreshToken(ctx, s.user.RefreshToken.RefreshToken)
+	return expectAPIError(err, http.StatusBadRequest)
+}
+
+// ============================================================================
+// 辅助函数
+// ============================================================================
+
+// requireUser 没有用户令牌时跳过检查
+func (s *suite) requireUser() error {
+	if s.user == nil {
+		return skipf("no user token: configure Login or RefreshToken")
+	}
+	return nil
+}
+
+// requireClientToken 没有客户端凭证令牌时跳过检查
+func (s *suite) requireClientToken() error {
+	if s.clientToken == "" {
+		return skipf("no client credentials token")
+	}
+	return nil
+}
+
+// validateTokenResponse 校验用户令牌响应的必填字段
+func validateTokenResponse(token *goauthsdk.TokenResponse) error {
+	if token.AccessToken.AccessToken == "" || token.AccessToken.ExpiresIn <= 0 {
+		return fmt.Errorf("incomplete response: access_token or expires_in is empty")
+	}
+	if token.RefreshToken.RefreshToken == "" {
+		return fmt.Errorf("incomplete response: refresh_token is empty")
+	}
+	return nil
+}
+
+// expectAPIError 校验请求以指定 HTTP 状态码的 APIError 失败
+func expectAPIError(err error, status int) error {
+	if err == nil {
+		return fmt.Errorf("got success, want HTTP %d error", status)
+	}
+	var apiErr *goauthsdk.APIError
+	if !errors.As(err, &apiErr) {
+		return fmt.Errorf("got %v, want *goauthsdk.APIError", err)
+	}
+	if apiErr.Status != status {
+		return fmt.Errorf("got HTTP %d (%v), want HTTP %d", apiErr.Status, err, status)
+	}
+	return nil
+}
+
+// unsupported 判断错误是否表示服务端未实现该端点或授权类型
+func unsupported(err error) bool {
+	var apiErr *goauthsdk.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.Status == http.StatusNotFound || apiErr.Status == http.StatusMethodNotAllowed ||
+		apiErr.Code == "unsupported_grant_type"
+}
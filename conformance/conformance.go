@@ -0,0 +1,186 @@
+// Package conformance 对 goauth 部署执行端到端一致性检查，验证 goauthsdk 与服务端的接口格式仍然匹配
+//
+// 检查覆盖 Client 的全部服务端接口（授权码、刷新、客户端凭证、设备授权、令牌交换、JWT Bearer、
+// 内省、撤销、用户信息、用户详情、PAR）以及错误路径（无效授权码、已撤销令牌、scope 不足、未知用户等）。
+// 需要用户参与的检查（授权码换取令牌、用户信息）依赖 Config.Login 或 Config.RefreshToken，均未配置时跳过。
+//
+// 可通过 cmd/goauthsdk-conformance 命令运行，也可在 Go 测试中调用 conformancetest.RunTests。
+package conformance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/3086953492/goauthsdk"
+)
+
+// 默认配置，与 cmd/goauthsdk-testserver 一致
+const (
+	DefaultFrontendBaseURL = "http://localhost:5173"
+	DefaultBackendBaseURL  = "http://localhost:9000"
+	DefaultScope           = "profile"
+	DefaultCheckTimeout    = 30 * time.Second
+)
+
+// Config 一致性检查的配置
+type Config struct {
+	FrontendBaseURL string // OAuth 前端站点地址，为空时使用 DefaultFrontendBaseURL
+	BackendBaseURL  string // OAuth 后端服务地址，为空时使用 DefaultBackendBaseURL
+	ClientID        string // 必填，客户端 ID
+	ClientSecret    string // 客户端密钥，客户端凭证相关检查需要
+	RedirectURI     string // 必填，客户端注册的回调地址
+
+	// AccessTokenSecret、RefreshTokenSecret 令牌签名密钥，配置后执行离线验签检查
+	AccessTokenSecret  string
+	RefreshTokenSecret string
+
+	// Scope 用户令牌与客户端凭证令牌申请的 scope，为空时使用 DefaultScope（用户详情接口需要 profile）
+	Scope string
+
+	// Login 模拟用户完成授权：访问授权地址并返回回调参数（code、state、iss 等）
+	// 为 nil 时跳过授权码相关检查；对接 goauthtest 时可直接使用 Server.Authorize
+	Login func(ctx context.Context, authURL string) (url.Values, error)
+
+	// RefreshToken 预先获取的刷新令牌，未配置 Login 时用于获取用户令牌
+	RefreshToken string
+
+	// Subject 已存在的用户标识，用于用户详情检查；为空时使用用户信息接口返回的 sub
+	Subject string
+
+	// CheckTimeout 单项检查的超时时间，为 0 时使用 DefaultCheckTimeout
+	CheckTimeout time.Duration
+
+	// ClientOptions 创建 Client 时追加的选项，例如 WithHTTPClient
+	ClientOptions []goauthsdk.ClientOption
+}
+
+// Status 检查结果状态
+type Status string
+
+// 检查结果状态
+const (
+	StatusPass Status = "PASS"
+	StatusFail Status = "FAIL"
+	StatusSkip Status = "SKIP"
+)
+
+// Result 单项检查的结果
+type Result struct {
+	Name     string        // 检查名称，例如 token/client_credentials
+	Status   Status        // 检查结果
+	Err      error         // 失败原因或跳过原因，通过时为 nil
+	Duration time.Duration // 耗时
+}
+
+// Report 一致性检查报告
+type Report struct {
+	Results []Result
+}
+
+// Failed 返回失败的检查数量
+func (r *Report) Failed() int {
+	n := 0
+	for _, result := range r.Results {
+		if result.Status == StatusFail {
+			n++
+		}
+	}
+	return n
+}
+
+// skipError 表示检查被跳过
+type skipError struct {
+	reason string
+}
+
+func (e *skipError) Error() string {
+	return e.reason
+}
+
+// skipf 返回跳过检查的错误
+func skipf(format string, args ...any) error {
+	return &skipError{reason: fmt.Sprintf(format, args...)}
+}
+
+// Run 依次执行全部检查并返回报告
+// 检查之间共享令牌等状态，因此按固定顺序串行执行；单项检查失败不会中断后续检查
+//
+// 返回:
+//   - *Report: 检查报告
+//   - error: 配置无效或无法创建 Client 时返回错误
+//
+// 示例用法:
+//
+//	report, err := conformance.Run(ctx, conformance.Config{
+//	    ClientID:     "1",
+//	    ClientSecret: "xxx",
+//	    RedirectURI:  "http://localhost:7000/callback",
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	report.Print(os.Stdout)
+func Run(ctx context.Context, cfg Config) (*Report, error) {
+	s, err := newSuite(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{}
+	for _, c := range checks {
+		report.Results = append(report.Results, s.run(ctx, c))
+	}
+	return report, nil
+}
+
+// newSuite 补全默认配置并创建 Client
+func newSuite(cfg Config) (*suite, error) {
+	if cfg.FrontendBaseURL == "" {
+		cfg.FrontendBaseURL = DefaultFrontendBaseURL
+	}
+	if cfg.BackendBaseURL == "" {
+		cfg.BackendBaseURL = DefaultBackendBaseURL
+	}
+	if cfg.Scope == "" {
+		cfg.Scope = DefaultScope
+	}
+	if cfg.CheckTimeout <= 0 {
+		cfg.CheckTimeout = DefaultCheckTimeout
+	}
+
+	opts := cfg.ClientOptions
+	if cfg.AccessTokenSecret != "" && cfg.RefreshTokenSecret != "" {
+		opts = append([]goauthsdk.ClientOption{goauthsdk.WithJWTSecrets(cfg.AccessTokenSecret, cfg.RefreshTokenSecret)}, opts...)
+	}
+	if cfg.ClientSecret == "" {
+		opts = append([]goauthsdk.ClientOption{goauthsdk.WithClientAuthMethod(goauthsdk.ClientAuthNone)}, opts...)
+	}
+
+	client, err := goauthsdk.NewClient(cfg.FrontendBaseURL, cfg.BackendBaseURL, cfg.ClientID, cfg.ClientSecret, cfg.RedirectURI, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("create client: %w", err)
+	}
+	return &suite{cfg: cfg, client: client}, nil
+}
+
+// run 执行单项检查，并将错误归类为失败或跳过
+func (s *suite) run(ctx context.Context, c check) Result {
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.CheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := c.fn(s, ctx)
+	result := Result{Name: c.name, Status: StatusPass, Err: err, Duration: time.Since(start)}
+
+	var skip *skipError
+	switch {
+	case errors.As(err, &skip):
+		result.Status = StatusSkip
+	case err != nil:
+		result.Status = StatusFail
+	}
+	return result
+}
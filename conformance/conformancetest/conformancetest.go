@@ -0,0 +1,47 @@
+// Package conformancetest 在 Go 测试中执行 conformance 检查
+// 与 conformance 包分离，避免命令行工具等非测试代码引入 testing 包
+package conformancetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/3086953492/goauthsdk/conformance"
+)
+
+// RunTests 在 Go 测试中执行全部检查，每项检查作为一个子测试
+// 无法创建 Client 时调用 t.Fatal；检查失败时子测试失败，跳过时子测试跳过
+//
+// 示例用法:
+//
+//	func TestConformance(t *testing.T) {
+//	    srv := goauthtest.NewServer()
+//	    defer srv.Close()
+//
+//	    conformancetest.RunTests(t, conformance.Config{
+//	        FrontendBaseURL: srv.URL,
+//	        BackendBaseURL:  srv.URL,
+//	        ClientID:        goauthtest.DefaultClientID,
+//	        ClientSecret:    goauthtest.DefaultClientSecret,
+//	        RedirectURI:     goauthtest.DefaultRedirectURI,
+//	        Login:           srv.Authorize,
+//	    })
+//	}
+func RunTests(t *testing.T, cfg conformance.Config) {
+	t.Helper()
+
+	report, err := conformance.Run(context.Background(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, result := range report.Results {
+		t.Run(result.Name, func(t *testing.T) {
+			switch result.Status {
+			case conformance.StatusFail:
+				t.Error(result.Err)
+			case conformance.StatusSkip:
+				t.Skip(result.Err)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package conformancetest
+
+import (
+	"testing"
+
+	"github.com/3086953492/goauthsdk/conformance"
+	"github.com/3086953492/goauthsdk/goauthtest"
+)
+
+// TestGoauthtestConformance 对 goauthtest 模拟服务执行全部检查，同时验证模拟服务签发的令牌可通过 SDK 离线验签
+func TestGoauthtestConformance(t *testing.T) {
+	srv := goauthtest.NewServer()
+	defer srv.Close()
+
+	RunTests(t, conformance.Config{
+		FrontendBaseURL:    srv.URL,
+		BackendBaseURL:     srv.URL,
+		ClientID:           goauthtest.DefaultClientID,
+		ClientSecret:       goauthtest.DefaultClientSecret,
+		RedirectURI:        goauthtest.DefaultRedirectURI,
+		AccessTokenSecret:  srv.AccessTokenSecret(),
+		RefreshTokenSecret: srv.RefreshTokenSecret(),
+		Login:              srv.Authorize,
+	})
+}
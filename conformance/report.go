@@ -0,0 +1,36 @@
+package conformance
+
+import (
+	"fmt"
+	"io"
+)
+
+// Print 以文本形式输出报告：每项检查一行，最后输出汇总
+//
+// 输出示例:
+//
+//	PASS  token/client_credentials           12ms
+//	FAIL  users/unknown_sub                  8ms  got HTTP 500 (...), want HTTP 404
+//	SKIP  token/authorization_code           0s   Login is not configured
+//
+//	28 passed, 1 failed, 1 skipped
+func (r *Report) Print(w io.Writer) {
+	var passed, failed, skipped int
+	for _, result := range r.Results {
+		switch result.Status {
+		case StatusPass:
+			passed++
+		case StatusFail:
+			failed++
+		case StatusSkip:
+			skipped++
+		}
+
+		line := fmt.Sprintf("%-4s  %-36s %v", result.Status, result.Name, result.Duration.Round(1e6))
+		if result.Err != nil {
+			line += "  " + result.Err.Error()
+		}
+		fmt.Fprintln(w, line)
+	}
+	fmt.Fprintf(w, "\n%d passed, %d failed, %d skipped\n", passed, failed, skipped)
+}
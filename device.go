@@ -0,0 +1,216 @@
+package goauthsdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/3086953492/goauthsdk/internal/httpx"
+)
+
+const (
+	// deviceCodeGrantType 是设备授权模式的 grant_type（RFC 8628 3.4）
+	deviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+	// defaultDevicePollInterval 是服务端未返回 interval 时的默认轮询间隔
+	defaultDevicePollInterval = 5 * time.Second
+
+	// devicePollSlowDownStep 是收到 slow_down 后增加的轮询间隔（RFC 8628 3.5）
+	devicePollSlowDownStep = 5 * time.Second
+)
+
+// CompleteVerificationURI 返回包含用户码的完整验证地址，适合生成二维码
+// 服务端返回了 verification_uri_complete 时直接使用，否则在 verification_uri 上拼接 user_code 参数
+func (d *DeviceAuthorizationResponse) CompleteVerificationURI() string {
+	if d.VerificationURIComplete != "" {
+		return d.VerificationURIComplete
+	}
+
+	u, err := url.Parse(d.VerificationURI)
+	if err != nil {
+		return d.VerificationURI
+	}
+	q := u.Query()
+	q.Set("user_code", d.UserCode)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// RequestDeviceAuthorization 发起设备授权请求（RFC 8628）
+// 适用于无法接收浏览器重定向的 CLI、TV 等设备：获取用户码与验证地址后展示给用户，
+// 再调用 PollDeviceToken 轮询令牌
+//
+// 参数:
+//   - ctx: 上下文，用于控制请求超时等
+//   - scope: 可选的权限范围，多个 scope 用空格分隔
+//
+// 示例用法:
+//
+//	auth, err := client.RequestDeviceAuthorization(ctx, "profile")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("请访问 %s 并输入用户码 %s\n", auth.VerificationURI, auth.UserCode)
+//	fmt.Printf("或扫描二维码：%s\n", auth.CompleteVerificationURI())
+//
+//	token, err := client.PollDeviceToken(ctx, auth)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func (c *Client) RequestDeviceAuthorization(ctx context.Context, scope string) (*DeviceAuthorizationResponse, error) {
+	// 构建并发送请求
+	req, err := buildDeviceAuthorizationRequest(ctx, c, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, body, err := doDeviceAuthorizationRequest(c, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// 解析响应
+	return parseDeviceAuthorizationResponse(resp, body)
+}
+
+// buildDeviceAuthorizationRequest 构建设备授权的 HTTP 请求
+func buildDeviceAuthorizationRequest(ctx context.Context, c *Client, scope string) (*http.Request, error) {
+	// 构建请求 URL
+	deviceURL := c.endpointURL(deviceAuthorizationEndpointPath)
+
+	// 构建表单参数
+	formData := url.Values{}
+	if scope != "" {
+		formData.Set("scope", scope)
+	}
+
+	// 创建 HTTP 请求（附加客户端认证信息）
+	req, err := newClientAuthRequest(ctx, c, deviceURL, formData)
+	if err != nil {
+		return nil, fmt.Errorf("create device authorization request: %w", err)
+	}
+
+	return req, nil
+}
+
+// doDeviceAuthorizationRequest 发送设备授权请求并返回响应与响应体
+func doDeviceAuthorizationRequest(c *Client, req *http.Request) (*http.Response, []byte, error) {
+	return httpx.Do(c.cfg.HTTPClient, req)
+}
+
+// parseDeviceAuthorizationResponse 解析设备授权响应
+// 响应格式：{ "code": 0, "message": "...", "data": { "device_code": "...", "user_code": "...", ... } }
+func parseDeviceAuthorizationResponse(resp *http.Response, body []byte) (*DeviceAuthorizationResponse, error) {
+	// 非 2xx：统一走 decodeAPIError
+	if resp.StatusCode != http.StatusOK {
+		return nil, decodeAPIError(resp, body)
+	}
+
+	// 解析响应
+	var apiResp apiCodeResponse[DeviceAuthorizationResponse]
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return nil, fmt.Errorf("parse device authorization response: %w", err)
+	}
+
+	// 检查业务是否成功（code == 0 表示成功）
+	if apiResp.Code != 0 {
+		return nil, newBusinessError(resp.StatusCode, apiResp.Code, apiResp.Message)
+	}
+
+	return &apiResp.Data, nil
+}
+
+// PollDeviceToken 轮询设备授权的令牌，直到用户完成授权、拒绝授权、设备码过期或 ctx 取消
+// 轮询间隔遵循服务端返回的 interval，收到 slow_down 时按 RFC 8628 增加 5 秒
+//
+// 参数:
+//   - ctx: 上下文，取消后立即停止轮询并返回 ctx.Err()
+//   - auth: RequestDeviceAuthorization 的返回值
+//
+// 返回:
+//   - *TokenResponse: 用户完成授权后签发的令牌
+//   - error: 用户拒绝返回的错误满足 errors.Is(err, ErrAccessDenied)；
+//     设备码过期返回的错误满足 errors.Is(err, ErrDeviceCodeExpired)
+func (c *Client) PollDeviceToken(ctx context.Context, auth *DeviceAuthorizationResponse) (*TokenResponse, error) {
+	if auth == nil || auth.DeviceCode == "" {
+		return nil, fmt.Errorf("device_code is required")
+	}
+
+	interval := defaultDevicePollInterval
+	if auth.Interval > 0 {
+		interval = time.Duration(auth.Interval) * time.Second
+	}
+
+	var deadline time.Time
+	if auth.ExpiresIn > 0 {
+		deadline = time.Now().Add(time.Duration(auth.ExpiresIn) * time.Second)
+	}
+
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			return nil, ErrDeviceCodeExpired
+		}
+
+		token, err := c.requestDeviceToken(ctx, auth.DeviceCode)
+		switch {
+		case err == nil:
+			return token, nil
+		case errors.Is(err, ErrAuthorizationPending):
+		case errors.Is(err, ErrSlowDown):
+			interval += devicePollSlowDownStep
+		default:
+			return nil, err
+		}
+
+		timer.Reset(interval)
+	}
+}
+
+// requestDeviceToken 使用设备码请求一次令牌
+func (c *Client) requestDeviceToken(ctx context.Context, deviceCode string) (*TokenResponse, error) {
+	// 构建并发送请求
+	req, err := buildDeviceTokenRequest(ctx, c, deviceCode)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, body, err := doTokenRequest(c, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// 解析响应
+	return parseTokenResponse(resp, body)
+}
+
+// buildDeviceTokenRequest 构建设备码换取令牌的 HTTP 请求
+func buildDeviceTokenRequest(ctx context.Context, c *Client, deviceCode string) (*http.Request, error) {
+	// 构建请求 URL
+	tokenURL := c.endpointURL(tokenEndpointPath)
+
+	// 构建表单参数
+	formData := url.Values{}
+	formData.Set("grant_type", deviceCodeGrantType)
+	formData.Set("device_code", deviceCode)
+
+	// 创建 HTTP 请求（附加客户端认证信息）
+	req, err := newClientAuthRequest(ctx, c, tokenURL, formData)
+	if err != nil {
+		return nil, fmt.Errorf("create device token request: %w", err)
+	}
+
+	return req, nil
+}
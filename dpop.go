@@ -0,0 +1,329 @@
+package goauthsdk
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/3086953492/goauthsdk/internal/cryptox"
+	"github.com/3086953492/goauthsdk/internal/httpx"
+	"github.com/3086953492/goauthsdk/internal/jwtx"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// dpopProofType 是 DPoP 证明的 JOSE typ 头部（RFC 9449 4.2）
+	dpopProofType = "dpop+jwt"
+
+	// dpopNonceError 是服务端要求携带 nonce 时返回的错误码（RFC 9449 8 / 9）
+	dpopNonceError = "use_dpop_nonce"
+)
+
+// DPoPKey 是用于签发 DPoP 证明（RFC 9449）的密钥对
+// 访问令牌绑定到该密钥的公钥指纹（cnf.jkt），令牌泄露后没有私钥的一方无法使用
+type DPoPKey struct {
+	signer     crypto.Signer
+	jwk        map[string]any
+	thumbprint string
+}
+
+// dpopProofClaims 是 DPoP 证明的声明（RFC 9449 4.2）
+type dpopProofClaims struct {
+	HTM   string `json:"htm"`             // 请求方法
+	HTU   string `json:"htu"`             // 请求地址（不含 query 与 fragment）
+	ATH   string `json:"ath,omitempty"`   // 访问令牌的 SHA-256 摘要，访问资源时必填
+	Nonce string `json:"nonce,omitempty"` // 服务端通过 DPoP-Nonce 下发的 nonce
+	jwt.RegisteredClaims
+}
+
+// NewDPoPKey 生成新的 ECDSA P-256 DPoP 密钥（ES256）
+// 如需在进程重启后继续使用已绑定的令牌，应持久化 PrivateKey() 并通过 NewDPoPKeyFromSigner 恢复
+func NewDPoPKey() (*DPoPKey, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generate dpop key: %w", err)
+	}
+	return NewDPoPKeyFromSigner(key)
+}
+
+// NewDPoPKeyFromSigner 使用已有私钥创建 DPoP 密钥
+// 支持 *ecdsa.PrivateKey（ES256/ES384/ES512）与 *rsa.PrivateKey（RS256）
+func NewDPoPKeyFromSigner(key crypto.Signer) (*DPoPKey, error) {
+	switch key.(type) {
+	case *ecdsa.PrivateKey, *rsa.PrivateKey:
+	default:
+		return nil, fmt.Errorf("unsupported dpop key type: %T", key)
+	}
+
+	jwk, err := jwtx.PublicJWK(key.Public())
+	if err != nil {
+		return nil, err
+	}
+	thumbprint, err := jwtx.Thumbprint(key.Public())
+	if err != nil {
+		return nil, err
+	}
+	return &DPoPKey{signer: key, jwk: jwk, thumbprint: thumbprint}, nil
+}
+
+// PrivateKey 返回私钥，用于持久化
+func (k *DPoPKey) PrivateKey() crypto.Signer {
+	return k.signer
+}
+
+// Thumbprint 返回公钥的 JWK SHA-256 指纹（RFC 7638），即绑定令牌的 cnf.jkt
+func (k *DPoPKey) Thumbprint() string {
+	return k.thumbprint
+}
+
+// Proof 签发一次性 DPoP 证明
+//
+// 参数:
+//   - method: 请求方法，例如 POST
+//   - targetURI: 请求地址，query 与 fragment 会被去除
+//   - accessToken: 访问受保护资源时使用的访问令牌；请求令牌端点时传空字符串
+//   - nonce: 服务端通过 DPoP-Nonce 下发的 nonce，没有时传空字符串
+func (k *DPoPKey) Proof(method, targetURI, accessToken, nonce string) (string, error) {
+	htu, err := normalizeHTU(targetURI)
+	if err != nil {
+		return "", err
+	}
+	jti, err := cryptox.RandomString(16)
+	if err != nil {
+		return "", err
+	}
+
+	claims := dpopProofClaims{
+		HTM:   method,
+		HTU:   htu,
+		Nonce: nonce,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:       jti,
+			IssuedAt: jwt.NewNumericDate(time.Now()),
+		},
+	}
+	if accessToken != "" {
+		claims.ATH = accessTokenHash(accessToken)
+	}
+
+	headers := map[string]any{
+		"typ": dpopProofType,
+		"jwk": k.jwk,
+	}
+	proof, err := jwtx.Sign(claims, k.signer, headers)
+	if err != nil {
+		return "", fmt.Errorf("sign dpop proof: %w", err)
+	}
+	return proof, nil
+}
+
+// DoResourceRequest 使用访问令牌访问受保护资源
+// 启用 DPoP 且令牌绑定到本客户端的 DPoP 密钥时使用 DPoP 认证方案并附加 DPoP 证明，服务端要求 nonce 时自动重试一次；
+// 否则使用 Bearer 认证方案
+//
+// 参数:
+//   - req: 资源请求；有请求体时需可重放（http.NewRequest 对常见 body 类型会自动设置 GetBody）
+//   - accessToken: 访问令牌
+//
+// 返回值:
+//   - *http.Response: 响应（Body 已被读取并关闭，但 StatusCode/Header 等仍可用）
+//   - []byte: 响应体内容
+//   - error: 发送请求失败时返回错误；非 2xx 响应不视为错误，由调用方根据 StatusCode 处理
+func (c *Client) DoResourceRequest(req *http.Request, accessToken string) (*http.Response, []byte, error) {
+	if accessToken == "" {
+		return nil, nil, fmt.Errorf("access_token is required")
+	}
+	setAccessTokenAuthorization(c, req, accessToken)
+	return doDPoPRequest(c, req)
+}
+
+// setAccessTokenAuthorization 设置访问令牌的 Authorization 头部
+// 启用 DPoP 且令牌绑定到本客户端的 DPoP 密钥时使用 DPoP 认证方案（RFC 9449 7.1），否则使用 Bearer
+func setAccessTokenAuthorization(c *Client, req *http.Request, accessToken string) {
+	if c.dpop != nil && c.dpop.binds(accessToken) {
+		req.Header.Set("Authorization", "DPoP "+accessToken)
+		return
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+}
+
+// doDPoPRequest 发送请求；启用 DPoP 时附加 DPoP 证明，并在服务端要求使用新 nonce 时携带该 nonce 重试一次
+// 令牌端点请求与资源请求统一通过该函数发送；Bearer 资源请求不附加证明
+func doDPoPRequest(c *Client, req *http.Request) (*http.Response, []byte, error) {
+	authorization := req.Header.Get("Authorization")
+	if c.dpop == nil || strings.HasPrefix(authorization, "Bearer ") {
+		return httpx.Do(c.cfg.HTTPClient, req)
+	}
+
+	// 资源请求的证明需要包含访问令牌摘要（ath）
+	accessToken, ok := strings.CutPrefix(authorization, "DPoP ")
+	if !ok {
+		accessToken = ""
+	}
+
+	usedNonce, err := c.dpop.attach(req, accessToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	resp, body, err := httpx.Do(c.cfg.HTTPClient, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 质询中的 nonce 与本次证明使用的 nonce 不同时重试；并发请求收到相同质询时各自重试
+	nonce := c.dpop.storeNonce(req.URL, resp)
+	if nonce == "" || nonce == usedNonce || !isDPoPNonceChallenge(resp, body) {
+		return resp, body, nil
+	}
+
+	retry, err := rewindRequest(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := c.dpop.attach(retry, accessToken); err != nil {
+		return nil, nil, err
+	}
+	return httpx.Do(c.cfg.HTTPClient, retry)
+}
+
+// rewindRequest 复制请求并通过 GetBody 重新获取请求体，用于重试
+// 客户端认证请求的 GetBody 会重新生成客户端断言（见 newClientAuthRequest）；有请求体但无法重放时返回错误
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	retry := req.Clone(req.Context())
+	if req.Body == nil || req.Body == http.NoBody {
+		return retry, nil
+	}
+	if req.GetBody == nil {
+		return nil, fmt.Errorf("request body cannot be replayed for retry")
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, fmt.Errorf("rewind request body: %w", err)
+	}
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("rewind request body: %w", err)
+	}
+
+	retry.Body = io.NopCloser(bytes.NewReader(data))
+	retry.ContentLength = int64(len(data))
+	retry.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+	return retry, nil
+}
+
+// isDPoPNonceChallenge 判断响应是否要求携带 DPoP nonce
+// 令牌端点返回 400 {"error":"use_dpop_nonce"}，资源服务器返回 401 与 WWW-Authenticate: DPoP error="use_dpop_nonce"
+func isDPoPNonceChallenge(resp *http.Response, body []byte) bool {
+	switch resp.StatusCode {
+	case http.StatusBadRequest:
+		var oauthErr oauthErrorResponse
+		return json.Unmarshal(body, &oauthErr) == nil && oauthErr.Error == dpopNonceError
+	case http.StatusUnauthorized:
+		return strings.Contains(resp.Header.Get("WWW-Authenticate"), dpopNonceError)
+	}
+	return false
+}
+
+// dpopSigner 为 Client 的请求附加 DPoP 证明，并按服务端记录最新的 nonce
+type dpopSigner struct {
+	key *DPoPKey
+
+	mu     sync.Mutex
+	nonces map[string]string // origin（scheme://host）-> nonce
+}
+
+// newDPoPSigner 创建 dpopSigner
+func newDPoPSigner(key crypto.Signer) (*dpopSigner, error) {
+	dpopKey, err := NewDPoPKeyFromSigner(key)
+	if err != nil {
+		return nil, err
+	}
+	return &dpopSigner{key: dpopKey, nonces: make(map[string]string)}, nil
+}
+
+// attach 签发证明并设置 DPoP 头部，返回证明中使用的 nonce
+func (s *dpopSigner) attach(req *http.Request, accessToken string) (string, error) {
+	s.mu.Lock()
+	nonce := s.nonces[origin(req.URL)]
+	s.mu.Unlock()
+
+	proof, err := s.key.Proof(req.Method, req.URL.String(), accessToken, nonce)
+	if err != nil {
+		return "", fmt.Errorf("create dpop proof: %w", err)
+	}
+	req.Header.Set("DPoP", proof)
+	return nonce, nil
+}
+
+// storeNonce 记录响应中的 DPoP-Nonce 并返回，未下发时返回空字符串
+func (s *dpopSigner) storeNonce(u *url.URL, resp *http.Response) string {
+	nonce := resp.Header.Get("DPoP-Nonce")
+	if nonce == "" {
+		return ""
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nonces[origin(u)] = nonce
+	return nonce
+}
+
+// binds 判断访问令牌是否绑定到本密钥
+// JWT 令牌比较 cnf.jkt 与公钥指纹；无法解析为 JWT 的不透明令牌无法判断，按已绑定处理
+func (s *dpopSigner) binds(accessToken string) bool {
+	var payload struct {
+		Cnf *Confirmation `json:"cnf"`
+	}
+	if err := jwtx.DecodePayload(accessToken, &payload); err != nil {
+		return true
+	}
+	return payload.Cnf != nil && payload.Cnf.JKT == s.key.Thumbprint()
+}
+
+// origin 返回 URL 的 scheme://host，nonce 按服务端区分
+func origin(u *url.URL) string {
+	return strings.ToLower(u.Scheme) + "://" + strings.ToLower(u.Host)
+}
+
+// normalizeHTU 去除 query 与 fragment，并将 scheme、host 转为小写（RFC 9449 4.3）
+func normalizeHTU(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("parse htu: %w", err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return "", fmt.Errorf("htu must be an absolute url")
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	u.RawQuery = ""
+	u.Fragment = ""
+	u.RawFragment = ""
+	if u.Path == "" {
+		u.Path = "/"
+	}
+	return u.String(), nil
+}
+
+// accessTokenHash 计算访问令牌的 ath（SHA-256 摘要，base64url 无填充）
+func accessTokenHash(accessToken string) string {
+	sum := sha256.Sum256([]byte(accessToken))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,191 @@
+package goauthsdk
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/3086953492/goauthsdk/internal/jwtx"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// dpopNonceServer 模拟要求 DPoP nonce 的令牌端点：证明未携带当前 nonce 时返回 use_dpop_nonce 质询
+type dpopNonceServer struct {
+	t     *testing.T
+	nonce string
+
+	mu         sync.Mutex
+	assertions []string // 每次请求的 client_assertion jti
+}
+
+func (s *dpopNonceServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		s.t.Error(err)
+	}
+	var assertion struct {
+		ID string `json:"jti"`
+	}
+	if raw := r.PostForm.Get("client_assertion"); raw != "" {
+		if err := jwtx.DecodePayload(raw, &assertion); err != nil {
+			s.t.Error(err)
+		}
+	}
+	s.mu.Lock()
+	s.assertions = append(s.assertions, assertion.ID)
+	s.mu.Unlock()
+
+	var proof struct {
+		Nonce string `json:"nonce"`
+	}
+	if err := jwtx.DecodePayload(r.Header.Get("DPoP"), &proof); err != nil {
+		s.t.Errorf("decode dpop proof: %v", err)
+	}
+
+	w.Header().Set("DPoP-Nonce", s.nonce)
+	w.Header().Set("Content-Type", "application/json")
+	if proof.Nonce != s.nonce {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":"use_dpop_nonce","error_description":"nonce required"}`))
+		return
+	}
+	_, _ = w.Write([]byte(`{"code":0,"message":"success","data":{"access_token":"token","expires_in":3600,"token_type":"DPoP","scope":""}}`))
+}
+
+func newDPoPTestClient(t *testing.T, backendURL string, opts ...ClientOption) *Client {
+	t.Helper()
+	key, err := NewDPoPKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := NewClient("https://portal.example.com", backendURL, "client-1", "client-secret-with-enough-length",
+		"https://app.example.com/callback", append([]ClientOption{WithDPoP(key)}, opts...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestDPoPNonceRetryUsesFreshClientAssertion(t *testing.T) {
+	handler := &dpopNonceServer{t: t, nonce: "nonce-1"}
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+
+	client := newDPoPTestClient(t, srv.URL, WithClientAuthMethod(ClientAuthSecretJWT))
+	if _, err := client.ClientCredentialsToken(context.Background(), ""); err != nil {
+		t.Fatalf("ClientCredentialsToken: %v", err)
+	}
+
+	if len(handler.assertions) != 2 {
+		t.Fatalf("requests = %d, want 2 (challenge and retry)", len(handler.assertions))
+	}
+	if handler.assertions[0] == "" || handler.assertions[0] == handler.assertions[1] {
+		t.Errorf("retry reused client assertion jti %q", handler.assertions[1])
+	}
+}
+
+func TestDPoPConcurrentNonceChallengesAllRetry(t *testing.T) {
+	const concurrency = 4
+	handler := &dpopNonceServer{t: t, nonce: "nonce-1"}
+
+	// 未携带 nonce 的首次请求全部到达后才一起返回质询，模拟并发请求收到相同的 nonce
+	var arrived sync.WaitGroup
+	arrived.Add(concurrency)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var proof struct {
+			Nonce string `json:"nonce"`
+		}
+		_ = jwtx.DecodePayload(r.Header.Get("DPoP"), &proof)
+		if proof.Nonce == "" {
+			arrived.Done()
+			arrived.Wait()
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	client := newDPoPTestClient(t, srv.URL)
+	var wg sync.WaitGroup
+	errs := make(chan error, concurrency)
+	for range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.ClientCredentialsToken(context.Background(), "")
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Errorf("ClientCredentialsToken: %v", err)
+		}
+	}
+}
+
+func TestDPoPRetryFailsWithoutReplayableBody(t *testing.T) {
+	handler := &dpopNonceServer{t: t, nonce: "nonce-1"}
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+
+	client := newDPoPTestClient(t, srv.URL)
+	req, err := http.NewRequest(http.MethodPost, srv.URL+"/resource", strings.NewReader("payload"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.GetBody = nil
+
+	if _, _, err := doDPoPRequest(client, req); err == nil || !strings.Contains(err.Error(), "cannot be replayed") {
+		t.Fatalf("got %v, want replay error", err)
+	}
+}
+
+func TestAccessTokenSchemeFollowsBinding(t *testing.T) {
+	key, err := NewDPoPKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := NewClient("https://portal.example.com", "https://auth.example.com", "client-1", "secret",
+		"https://app.example.com/callback", WithDPoP(key))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sign := func(claims jwt.MapClaims) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("secret"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+	tests := []struct {
+		name   string
+		token  string
+		scheme string
+	}{
+		{"bound", sign(jwt.MapClaims{"sub": "u", "cnf": map[string]any{"jkt": key.Thumbprint()}}), "DPoP"},
+		{"bearer", sign(jwt.MapClaims{"sub": "u"}), "Bearer"},
+		{"other key", sign(jwt.MapClaims{"sub": "u", "cnf": map[string]any{"jkt": "other"}}), "Bearer"},
+		{"opaque", "opaque-token", "DPoP"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "https://api.example.com/", nil)
+			setAccessTokenAuthorization(client, req, tt.token)
+			if got := req.Header.Get("Authorization"); got != tt.scheme+" "+tt.token {
+				t.Errorf("Authorization = %q, want scheme %s", got, tt.scheme)
+			}
+		})
+	}
+}
+
+func TestWithDPoPNilKey(t *testing.T) {
+	_, err := NewClient("https://portal.example.com", "https://auth.example.com", "client-1", "secret",
+		"https://app.example.com/callback", WithDPoP(nil))
+	if err == nil || !strings.Contains(err.Error(), "dpop key is required") {
+		t.Fatalf("got %v, want dpop key error", err)
+	}
+}
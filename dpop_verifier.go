@@ -0,0 +1,262 @@
+package goauthsdk
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/3086953492/goauthsdk/internal/jwtx"
+	gokitjwt "github.com/3086953492/gokit/jwt"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// DefaultDPoPProofMaxAge 是 DPoP 证明的默认最大有效时长（按 iat 计算）
+	DefaultDPoPProofMaxAge = 5 * time.Minute
+
+	// dpopClockSkew 是允许证明 iat 超前服务端时间的范围
+	dpopClockSkew = time.Minute
+)
+
+// dpopSigningMethods 是 DPoP 证明允许的签名算法，只接受非对称算法（RFC 9449 4.3）
+var dpopSigningMethods = []string{"ES256", "ES384", "ES512", "RS256", "RS384", "RS512", "PS256", "PS384", "PS512"}
+
+var (
+	// ErrInvalidDPoPProof 表示 DPoP 证明无效（格式、签名、htm/htu/ath、iat 校验失败）
+	ErrInvalidDPoPProof = errors.New("invalid dpop proof")
+
+	// ErrDPoPProofReplayed 表示 DPoP 证明的 jti 已被使用过
+	ErrDPoPProofReplayed = errors.New("dpop proof replayed")
+
+	// ErrDPoPBindingMismatch 表示访问令牌绑定的 DPoP 公钥与证明的公钥不一致，或令牌未绑定 DPoP 公钥
+	ErrDPoPBindingMismatch = errors.New("access token is not bound to the dpop key")
+)
+
+// DPoPProof 是校验通过的 DPoP 证明
+type DPoPProof struct {
+	JKT      string    // 证明公钥的 JWK SHA-256 指纹，应与访问令牌的 cnf.jkt 一致
+	JTI      string    // 证明的唯一标识
+	HTM      string    // 请求方法
+	HTU      string    // 请求地址
+	Nonce    string    // 证明携带的 nonce
+	IssuedAt time.Time // 签发时间
+}
+
+// DPoPVerifier 在资源服务器或授权服务器侧校验 DPoP 证明（RFC 9449 4.3）
+type DPoPVerifier struct {
+	replay ReplayCache
+	maxAge time.Duration
+}
+
+// DPoPVerifierOption 用于配置 DPoPVerifier 的可选参数
+type DPoPVerifierOption func(*DPoPVerifier)
+
+// WithDPoPReplayCache 设置用于检测证明重放的缓存，多实例部署时应使用共享存储实现
+// 不设置时使用 NewMemoryReplayCache
+func WithDPoPReplayCache(cache ReplayCache) DPoPVerifierOption {
+	return func(v *DPoPVerifier) {
+		v.replay = cache
+	}
+}
+
+// WithDPoPProofMaxAge 设置证明的最大有效时长，默认 DefaultDPoPProofMaxAge
+func WithDPoPProofMaxAge(d time.Duration) DPoPVerifierOption {
+	return func(v *DPoPVerifier) {
+		v.maxAge = d
+	}
+}
+
+// NewDPoPVerifier 创建 DPoPVerifier
+func NewDPoPVerifier(opts ...DPoPVerifierOption) *DPoPVerifier {
+	v := &DPoPVerifier{maxAge: DefaultDPoPProofMaxAge}
+	for _, opt := range opts {
+		opt(v)
+	}
+	if v.replay == nil {
+		v.replay = NewMemoryReplayCache()
+	}
+	return v
+}
+
+// VerifyRequest 从资源请求中取出 DPoP 访问令牌并校验 DPoP 证明
+// 请求需使用 Authorization: DPoP <token> 且携带唯一的 DPoP 头部；htu 根据 r.TLS、r.Host 与 r.URL.Path 还原，
+// 部署在反向代理之后时应改用 VerifyProof 并传入对外地址
+//
+// 返回值:
+//   - string: 访问令牌，可继续传给 ParseDPoPBoundAccessToken 或 IntrospectToken
+//   - *DPoPProof: 校验通过的证明
+//   - error: 校验失败时返回的错误满足 errors.Is(err, ErrInvalidDPoPProof) 或 errors.Is(err, ErrDPoPProofReplayed)
+//
+// 示例用法:
+//
+//	token, proof, err := verifier.VerifyRequest(r)
+//	if err != nil {
+//	    http.Error(w, "invalid dpop proof", http.StatusUnauthorized)
+//	    return
+//	}
+//	claims, err := client.ParseDPoPBoundAccessToken(token, proof)
+func (v *DPoPVerifier) VerifyRequest(r *http.Request) (string, *DPoPProof, error) {
+	accessToken, ok := strings.CutPrefix(r.Header.Get("Authorization"), "DPoP ")
+	if !ok || accessToken == "" {
+		return "", nil, fmt.Errorf("%w: dpop authorization scheme is required", ErrInvalidDPoPProof)
+	}
+
+	proofs := r.Header.Values("DPoP")
+	if len(proofs) != 1 {
+		return "", nil, fmt.Errorf("%w: exactly one dpop header is required", ErrInvalidDPoPProof)
+	}
+
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	targetURI := scheme + "://" + r.Host + r.URL.Path
+
+	proof, err := v.VerifyProof(r.Context(), proofs[0], r.Method, targetURI, accessToken)
+	if err != nil {
+		return "", nil, err
+	}
+	return accessToken, proof, nil
+}
+
+// VerifyProof 校验 DPoP 证明
+//
+// 参数:
+//   - ctx: 上下文，传给 ReplayCache
+//   - proof: DPoP 头部的值
+//   - method: 实际请求方法
+//   - targetURI: 实际请求地址（query 与 fragment 会被忽略）
+//   - accessToken: 请求携带的访问令牌，用于校验 ath；校验令牌端点请求时传空字符串
+func (v *DPoPVerifier) VerifyProof(ctx context.Context, proof, method, targetURI, accessToken string) (*DPoPProof, error) {
+	var (
+		claims     dpopProofClaims
+		thumbprint string
+	)
+	_, err := jwt.ParseWithClaims(proof, &claims, func(token *jwt.Token) (any, error) {
+		if typ, _ := token.Header["typ"].(string); typ != dpopProofType {
+			return nil, fmt.Errorf("typ must be %s", dpopProofType)
+		}
+		jwk, ok := token.Header["jwk"].(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("jwk header is required")
+		}
+		pub, err := jwtx.ParseJWK(jwk)
+		if err != nil {
+			return nil, err
+		}
+		thumbprint, err = jwtx.Thumbprint(pub)
+		if err != nil {
+			return nil, err
+		}
+		return pub, nil
+	}, jwt.WithValidMethods(dpopSigningMethods), jwt.WithoutClaimsValidation())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidDPoPProof, err)
+	}
+
+	if claims.ID == "" || claims.IssuedAt == nil {
+		return nil, fmt.Errorf("%w: jti and iat are required", ErrInvalidDPoPProof)
+	}
+	if claims.HTM != method {
+		return nil, fmt.Errorf("%w: htm mismatch", ErrInvalidDPoPProof)
+	}
+	expectedHTU, err := normalizeHTU(targetURI)
+	if err != nil {
+		return nil, err
+	}
+	actualHTU, err := normalizeHTU(claims.HTU)
+	if err != nil || actualHTU != expectedHTU {
+		return nil, fmt.Errorf("%w: htu mismatch", ErrInvalidDPoPProof)
+	}
+
+	now := time.Now()
+	issuedAt := claims.IssuedAt.Time
+	if issuedAt.Before(now.Add(-v.maxAge)) || issuedAt.After(now.Add(dpopClockSkew)) {
+		return nil, fmt.Errorf("%w: iat out of range", ErrInvalidDPoPProof)
+	}
+
+	if accessToken != "" {
+		expected := accessTokenHash(accessToken)
+		if subtle.ConstantTimeCompare([]byte(claims.ATH), []byte(expected)) != 1 {
+			return nil, fmt.Errorf("%w: ath mismatch", ErrInvalidDPoPProof)
+		}
+	}
+
+	// jti 仅在同一公钥内唯一，重放检测按 jkt + jti 记录
+	fresh, err := v.replay.Add(ctx, thumbprint+"\x00"+claims.ID, issuedAt.Add(v.maxAge+dpopClockSkew))
+	if err != nil {
+		return nil, fmt.Errorf("check dpop proof replay: %w", err)
+	}
+	if !fresh {
+		return nil, ErrDPoPProofReplayed
+	}
+
+	return &DPoPProof{
+		JKT:      thumbprint,
+		JTI:      claims.ID,
+		HTM:      claims.HTM,
+		HTU:      claims.HTU,
+		Nonce:    claims.Nonce,
+		IssuedAt: issuedAt,
+	}, nil
+}
+
+// VerifyDPoPBinding 校验 cnf 声明绑定的 DPoP 公钥与证明一致
+// 适用于通过 Introspect 获得的 IntrospectionResponse.Cnf；令牌未绑定 DPoP 公钥时返回 ErrDPoPBindingMismatch
+func VerifyDPoPBinding(cnf *Confirmation, proof *DPoPProof) error {
+	if proof == nil {
+		return fmt.Errorf("dpop proof is required")
+	}
+	if cnf == nil || cnf.JKT == "" {
+		return ErrDPoPBindingMismatch
+	}
+	if subtle.ConstantTimeCompare([]byte(cnf.JKT), []byte(proof.JKT)) != 1 {
+		return ErrDPoPBindingMismatch
+	}
+	return nil
+}
+
+// ParseDPoPBoundAccessToken 离线解析访问令牌，并校验其 cnf.jkt 与已校验的 DPoP 证明一致（RFC 9449 6）
+//
+// 参数:
+//   - token: 需要解析的访问令牌字符串
+//   - proof: DPoPVerifier 校验通过的证明
+func (v *JWTVerifier) ParseDPoPBoundAccessToken(token string, proof *DPoPProof) (*gokitjwt.Claims, error) {
+	claims, err := v.ParseAccessToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	// 签名已校验，可以安全读取 cnf
+	var payload struct {
+		Cnf *Confirmation `json:"cnf"`
+	}
+	if err := jwtx.DecodePayload(token, &payload); err != nil {
+		return nil, err
+	}
+	if err := VerifyDPoPBinding(payload.Cnf, proof); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// ParseDPoPBoundAccessToken 离线解析 DPoP 绑定的访问令牌（RFC 9449）
+// 在 ParseAccessToken 的基础上校验令牌的 cnf.jkt 与 DPoP 证明的公钥一致
+//
+// 参数:
+//   - token: 需要解析的访问令牌字符串
+//   - proof: DPoPVerifier 校验通过的证明
+//
+// 返回值:
+//   - *jwt.Claims: 解析出的令牌声明
+//   - error: 令牌无效时返回解析错误；未绑定或公钥不一致时返回的错误满足 errors.Is(err, ErrDPoPBindingMismatch)
+func (c *Client) ParseDPoPBoundAccessToken(token string, proof *DPoPProof) (*gokitjwt.Claims, error) {
+	if c.jwtVerifier == nil {
+		return nil, ErrJWTNotConfigured
+	}
+	return c.jwtVerifier.ParseDPoPBoundAccessToken(token, proof)
+}
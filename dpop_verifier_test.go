@@ -0,0 +1,302 @@
+package goauthsdk
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"maps"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/3086953492/goauthsdk/internal/jwtx"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testAccessTokenSecret = "access-token-secret-with-32-bytes!"
+
+// dpopProofBuilder 构造测试用的 DPoP 证明，可覆盖任意头部与声明
+type dpopProofBuilder struct {
+	t   *testing.T
+	key *ecdsa.PrivateKey
+	jwk map[string]any
+	jkt string
+}
+
+func newDPoPProofBuilder(t *testing.T) *dpopProofBuilder {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jwk, err := jwtx.PublicJWK(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jkt, err := jwtx.Thumbprint(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &dpopProofBuilder{t: t, key: key, jwk: jwk, jkt: jkt}
+}
+
+// build 签发证明；claims 与 headers 中的值覆盖默认值，值为 nil 时删除对应字段
+func (b *dpopProofBuilder) build(claims, headers map[string]any) string {
+	b.t.Helper()
+	jti, err := randomJTI()
+	if err != nil {
+		b.t.Fatal(err)
+	}
+	c := jwt.MapClaims{
+		"jti": jti,
+		"htm": "GET",
+		"htu": "https://api.example.com/orders",
+		"iat": time.Now().Unix(),
+		"ath": accessTokenHash("access-token"),
+	}
+	h := map[string]any{"typ": dpopProofType, "jwk": b.jwk}
+	for name, value := range claims {
+		if value == nil {
+			delete(c, name)
+		} else {
+			c[name] = value
+		}
+	}
+	for name, value := range headers {
+		if value == nil {
+			delete(h, name)
+		} else {
+			h[name] = value
+		}
+	}
+
+	proof, err := jwtx.Sign(c, b.key, h)
+	if err != nil {
+		b.t.Fatal(err)
+	}
+	return proof
+}
+
+// randomJTI 返回随机的 jti
+func randomJTI() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return accessTokenHash(string(buf)), nil
+}
+
+func TestDPoPVerifierVerifyProof(t *testing.T) {
+	b := newDPoPProofBuilder(t)
+	privateJWK := maps.Clone(b.jwk)
+	privateJWK["d"] = "AAAA"
+
+	hmacProof := func() string {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"jti": "hmac", "htm": "GET", "htu": "https://api.example.com/orders", "iat": time.Now().Unix(),
+		})
+		token.Header["typ"] = dpopProofType
+		token.Header["jwk"] = b.jwk
+		proof, err := token.SignedString([]byte("secret"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return proof
+	}
+
+	tests := []struct {
+		name        string
+		proof       string
+		method      string
+		targetURI   string
+		accessToken string
+		wantErr     error
+	}{
+		{"valid", b.build(nil, nil), "GET", "https://api.example.com/orders", "access-token", nil},
+		{"htu ignores query", b.build(nil, nil), "GET", "https://api.example.com/orders?page=2", "access-token", nil},
+		{"token endpoint without ath", b.build(map[string]any{"ath": nil}, nil), "GET", "https://api.example.com/orders", "", nil},
+		{"wrong htm", b.build(nil, nil), "POST", "https://api.example.com/orders", "access-token", ErrInvalidDPoPProof},
+		{"wrong htu path", b.build(nil, nil), "GET", "https://api.example.com/users", "access-token", ErrInvalidDPoPProof},
+		{"wrong htu host", b.build(nil, nil), "GET", "https://evil.example.com/orders", "access-token", ErrInvalidDPoPProof},
+		{"ath mismatch", b.build(nil, nil), "GET", "https://api.example.com/orders", "other-token", ErrInvalidDPoPProof},
+		{"missing ath", b.build(map[string]any{"ath": nil}, nil), "GET", "https://api.example.com/orders", "access-token", ErrInvalidDPoPProof},
+		{"stale iat", b.build(map[string]any{"iat": time.Now().Add(-DefaultDPoPProofMaxAge - time.Minute).Unix()}, nil), "GET", "https://api.example.com/orders", "access-token", ErrInvalidDPoPProof},
+		{"future iat", b.build(map[string]any{"iat": time.Now().Add(dpopClockSkew + time.Minute).Unix()}, nil), "GET", "https://api.example.com/orders", "access-token", ErrInvalidDPoPProof},
+		{"missing jti", b.build(map[string]any{"jti": nil}, nil), "GET", "https://api.example.com/orders", "access-token", ErrInvalidDPoPProof},
+		{"bad typ", b.build(nil, map[string]any{"typ": "JWT"}), "GET", "https://api.example.com/orders", "access-token", ErrInvalidDPoPProof},
+		{"missing jwk", b.build(nil, map[string]any{"jwk": nil}), "GET", "https://api.example.com/orders", "access-token", ErrInvalidDPoPProof},
+		{"private key in jwk", b.build(nil, map[string]any{"jwk": privateJWK}), "GET", "https://api.example.com/orders", "access-token", ErrInvalidDPoPProof},
+		{"symmetric alg", hmacProof(), "GET", "https://api.example.com/orders", "", ErrInvalidDPoPProof},
+		{"signed by other key", newDPoPProofBuilder(t).build(nil, map[string]any{"jwk": b.jwk}), "GET", "https://api.example.com/orders", "access-token", ErrInvalidDPoPProof},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier := NewDPoPVerifier()
+			proof, err := verifier.VerifyProof(context.Background(), tt.proof, tt.method, tt.targetURI, tt.accessToken)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("got %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if proof.JKT != b.jkt {
+				t.Errorf("jkt = %s, want %s", proof.JKT, b.jkt)
+			}
+		})
+	}
+}
+
+func TestDPoPVerifierRejectsReplayedJTI(t *testing.T) {
+	b := newDPoPProofBuilder(t)
+	verifier := NewDPoPVerifier()
+	proof := b.build(nil, nil)
+
+	if _, err := verifier.VerifyProof(context.Background(), proof, "GET", "https://api.example.com/orders", "access-token"); err != nil {
+		t.Fatal(err)
+	}
+	_, err := verifier.VerifyProof(context.Background(), proof, "GET", "https://api.example.com/orders", "access-token")
+	if !errors.Is(err, ErrDPoPProofReplayed) {
+		t.Fatalf("got %v, want ErrDPoPProofReplayed", err)
+	}
+
+	// jti 只在同一公钥内唯一，其他密钥使用相同 jti 不视为重放
+	other := newDPoPProofBuilder(t).build(map[string]any{"jti": "shared"}, nil)
+	if _, err := verifier.VerifyProof(context.Background(), b.build(map[string]any{"jti": "shared"}, nil), "GET", "https://api.example.com/orders", "access-token"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := verifier.VerifyProof(context.Background(), other, "GET", "https://api.example.com/orders", "access-token"); err != nil {
+		t.Fatalf("same jti from another key: %v", err)
+	}
+}
+
+func TestDPoPVerifierVerifyRequest(t *testing.T) {
+	b := newDPoPProofBuilder(t)
+
+	tests := []struct {
+		name          string
+		authorization string
+		proofs        []string
+		wantErr       bool
+	}{
+		{"valid", "DPoP access-token", []string{b.build(map[string]any{"htu": "http://api.example.com/orders"}, nil)}, false},
+		{"bearer scheme", "Bearer access-token", []string{b.build(map[string]any{"htu": "http://api.example.com/orders"}, nil)}, true},
+		{"missing proof", "DPoP access-token", nil, true},
+		{"multiple proofs", "DPoP access-token", []string{
+			b.build(map[string]any{"htu": "http://api.example.com/orders"}, nil),
+			b.build(map[string]any{"htu": "http://api.example.com/orders"}, nil),
+		}, true},
+		{"https htu over http", "DPoP access-token", []string{b.build(nil, nil)}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "http://api.example.com/orders?page=1", nil)
+			r.Header.Set("Authorization", tt.authorization)
+			for _, proof := range tt.proofs {
+				r.Header.Add("DPoP", proof)
+			}
+
+			token, proof, err := NewDPoPVerifier().VerifyRequest(r)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidDPoPProof) {
+					t.Fatalf("got %v, want ErrInvalidDPoPProof", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if token != "access-token" || proof.JKT != b.jkt {
+				t.Errorf("token = %q, jkt = %q", token, proof.JKT)
+			}
+		})
+	}
+}
+
+func TestParseDPoPBoundAccessToken(t *testing.T) {
+	client, err := NewClient("https://portal.example.com", "https://auth.example.com", "client-1", "client-secret",
+		"https://app.example.com/callback", WithAccessTokenSecret(testAccessTokenSecret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := newDPoPProofBuilder(t)
+
+	signAccessToken := func(cnf map[string]any) string {
+		claims := jwt.MapClaims{
+			"token_type": "access",
+			"sub":        "user-1",
+			"iat":        time.Now().Unix(),
+			"exp":        time.Now().Add(time.Hour).Unix(),
+		}
+		if cnf != nil {
+			claims["cnf"] = cnf
+		}
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testAccessTokenSecret))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{"bound", signAccessToken(map[string]any{"jkt": b.jkt}), nil},
+		{"jkt mismatch", signAccessToken(map[string]any{"jkt": newDPoPProofBuilder(t).jkt}), ErrDPoPBindingMismatch},
+		{"not bound", signAccessToken(nil), ErrDPoPBindingMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accessToken := tt.token
+			proof, err := NewDPoPVerifier().VerifyProof(context.Background(),
+				b.build(map[string]any{"ath": accessTokenHash(accessToken)}, nil), "GET", "https://api.example.com/orders", accessToken)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			claims, err := client.ParseDPoPBoundAccessToken(accessToken, proof)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("got %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || claims.Subject != "user-1" {
+				t.Fatalf("claims = %v, err = %v", claims, err)
+			}
+		})
+	}
+
+	// 签名无效的令牌在校验绑定前即被拒绝
+	forged, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"token_type": "access", "sub": "user-1", "cnf": map[string]any{"jkt": b.jkt},
+	}).SignedString([]byte("other-secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.ParseDPoPBoundAccessToken(forged, &DPoPProof{JKT: b.jkt}); err == nil {
+		t.Fatal("forged token accepted")
+	}
+}
+
+func TestMemoryReplayCacheExpiredEntries(t *testing.T) {
+	cache := NewMemoryReplayCache()
+	ctx := context.Background()
+
+	if fresh, err := cache.Add(ctx, "expired", time.Now().Add(-time.Second)); err != nil || !fresh {
+		t.Fatalf("Add = %v, %v", fresh, err)
+	}
+	// 未到清理间隔时，已过期的条目也不视为重放
+	if fresh, err := cache.Add(ctx, "expired", time.Now().Add(time.Minute)); err != nil || !fresh {
+		t.Fatalf("Add expired key = %v, %v; want fresh", fresh, err)
+	}
+	if fresh, err := cache.Add(ctx, "expired", time.Now().Add(time.Minute)); err != nil || fresh {
+		t.Fatalf("Add live key = %v, %v; want replay", fresh, err)
+	}
+}
@@ -0,0 +1,33 @@
+package goauthsdk
+
+// goauth 后端 OAuth 端点路径（相对于 BackendBaseURL）
+const (
+	tokenEndpointPath               = "/api/v1/oauth/token"
+	introspectionEndpointPath       = "/api/v1/oauth/introspect"
+	revocationEndpointPath          = "/api/v1/oauth/revoke"
+	userInfoEndpointPath            = "/api/v1/oauth/userinfo"
+	deviceAuthorizationEndpointPath = "/api/v1/oauth/device_authorization"
+	pushedAuthorizationEndpointPath = "/api/v1/oauth/par"
+)
+
+// endpointURL 返回端点的完整地址
+// 客户端使用 mTLS（证书认证或出示证书以获取证书绑定令牌）且配置了端点别名（WithMTLSEndpointAliases）时优先使用别名，
+// 否则拼接 BackendBaseURL
+func (c *Client) endpointURL(path string) string {
+	if c.usesMTLS() {
+		if alias := c.cfg.MTLSEndpointAliases[path]; alias != "" {
+			return alias
+		}
+	}
+	return c.cfg.BackendBaseURL + path
+}
+
+// usesMTLS 判断客户端是否通过 mTLS 访问授权服务器（RFC 8705 5）
+// 使用 tls_client_auth / self_signed_tls_client_auth 认证，或通过 WithTLSClientCertificate 出示证书时为 true
+func (c *Client) usesMTLS() bool {
+	switch c.cfg.ClientAuthMethod {
+	case ClientAuthTLS, ClientAuthSelfSignedTLS:
+		return true
+	}
+	return c.cfg.TLSClientCertificate != nil
+}
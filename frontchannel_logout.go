@@ -0,0 +1,133 @@
+package goauthsdk
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// FrontChannelLogoutHandler 处理授权服务器通过 iframe 发起的前端通道登出（OIDC Front-Channel Logout）
+// 授权服务器在登出页中以 iframe 加载客户端注册的 frontchannel_logout_uri，并附带 iss 与 sid 参数；
+// 处理器校验参数后调用应用提供的回调清理本地会话（通常是删除会话 Cookie）
+//
+// 注意：请求来自第三方 iframe，会话 Cookie 需设置 SameSite=None; Secure 才会被浏览器携带
+type FrontChannelLogoutHandler struct {
+	client          *Client
+	onLogout        func(w http.ResponseWriter, r *http.Request, event *LogoutEvent) error
+	sessionRequired bool
+}
+
+// FrontChannelLogoutOption 用于配置 FrontChannelLogoutHandler 的可选参数
+type FrontChannelLogoutOption func(*FrontChannelLogoutHandler)
+
+// WithFrontChannelSessionRequired 要求请求必须携带 iss 与 sid（对应注册元数据 frontchannel_logout_session_required）
+func WithFrontChannelSessionRequired() FrontChannelLogoutOption {
+	return func(h *FrontChannelLogoutHandler) {
+		h.sessionRequired = true
+	}
+}
+
+// NewFrontChannelLogoutHandler 创建前端通道登出处理器
+//
+// 参数:
+//   - client: Client；配置了 WithIssuer 时要求请求携带一致的 iss 与 sid
+//   - onLogout: 登出回调，event 只包含 Issuer 与 SessionID（未配置 WithIssuer 时可能为空）；
+//     SessionID 为空时应清理当前浏览器的本地会话，否则只清理 sid 匹配的会话；
+//     回调可以自行写出响应，未写出时处理器返回 200
+//   - opts: 可选配置
+//
+// 示例用法:
+//
+//	handler, err := goauthsdk.NewFrontChannelLogoutHandler(client,
+//	    func(w http.ResponseWriter, r *http.Request, event *goauthsdk.LogoutEvent) error {
+//	        return sessions.Destroy(w, r)
+//	    },
+//	)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	mux.Handle("/frontchannel-logout", handler)
+func NewFrontChannelLogoutHandler(client *Client, onLogout func(w http.ResponseWriter, r *http.Request, event *LogoutEvent) error, opts ...FrontChannelLogoutOption) (*FrontChannelLogoutHandler, error) {
+	if client == nil {
+		return nil, fmt.Errorf("client is required")
+	}
+	if onLogout == nil {
+		return nil, fmt.Errorf("logout callback is required")
+	}
+
+	h := &FrontChannelLogoutHandler{client: client, onLogout: onLogout}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h, nil
+}
+
+// ServeHTTP 处理 GET 请求的 iss、sid 参数
+// 响应禁止缓存（OIDC Front-Channel Logout 2）；参数无效返回 400，回调失败返回 500；
+// 回调已写出响应时不再覆盖状态码
+func (h *FrontChannelLogoutHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "no-cache, no-store")
+	w.Header().Set("Pragma", "no-cache")
+
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	event := &LogoutEvent{
+		Issuer:    query.Get("iss"),
+		SessionID: query.Get("sid"),
+	}
+
+	// iss 与 sid 必须同时出现或同时缺省
+	if (event.Issuer == "") != (event.SessionID == "") {
+		http.Error(w, "iss and sid must be provided together", http.StatusBadRequest)
+		return
+	}
+	if h.sessionRequired && event.SessionID == "" {
+		http.Error(w, "iss and sid are required", http.StatusBadRequest)
+		return
+	}
+	// 配置了 issuer 时必须携带 iss（因而也必须携带 sid），防止伪造的无参数请求清理当前浏览器的全部会话
+	if h.client.cfg.Issuer != "" && event.Issuer != h.client.cfg.Issuer {
+		http.Error(w, ErrIssuerMismatch.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// 回调可能已自行写出响应（例如渲染登出完成页面），此时不再追加状态码
+	tw := &trackingResponseWriter{ResponseWriter: w}
+	if err := h.onLogout(tw, r, event); err != nil {
+		if !tw.wroteHeader {
+			http.Error(w, "failed to terminate session", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if !tw.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// trackingResponseWriter 记录是否已写出响应头
+type trackingResponseWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+// WriteHeader 实现 http.ResponseWriter
+func (w *trackingResponseWriter) WriteHeader(code int) {
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write 实现 http.ResponseWriter；未调用 WriteHeader 时隐式写出 200
+func (w *trackingResponseWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap 返回底层 ResponseWriter，供 http.ResponseController 使用
+func (w *trackingResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
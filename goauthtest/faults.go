@@ -0,0 +1,97 @@
+package goauthtest
+
+import (
+	"net/http"
+	"time"
+)
+
+// Endpoint 标识模拟服务的端点，用于 InjectFault
+type Endpoint string
+
+// 模拟服务的端点
+const (
+	EndpointAuthorize           Endpoint = "authorize"
+	EndpointToken               Endpoint = "token"
+	EndpointDeviceAuthorization Endpoint = "device_authorization"
+	EndpointIntrospect          Endpoint = "introspect"
+	EndpointRevoke              Endpoint = "revoke"
+	EndpointUserInfo            Endpoint = "userinfo"
+	EndpointUsers               Endpoint = "users"
+)
+
+// Fault 描述注入到端点的故障
+// Status 为 0 时只注入延迟，请求照常处理；否则返回 RFC 7807 错误响应
+type Fault struct {
+	Status  int           // 返回的 HTTP 状态码
+	Code    string        // 错误码，写入 problem 的 code 字段，例如 invalid_grant、INTERNAL_ERROR
+	Detail  string        // 错误详情
+	Latency time.Duration // 处理请求前的延迟，请求 ctx 取消时提前返回
+	Times   int           // 生效次数，0 表示一直生效直到 ClearFaults
+}
+
+// InjectFault 为端点注入故障；同一端点的多个故障按注入顺序依次生效
+//
+// 示例用法:
+//
+//	// 下一次令牌请求返回 503
+//	srv.InjectFault(goauthtest.EndpointToken, goauthtest.Fault{Status: 503, Code: "UNAVAILABLE", Times: 1})
+//
+//	// 内省请求一直延迟 2 秒
+//	srv.InjectFault(goauthtest.EndpointIntrospect, goauthtest.Fault{Latency: 2 * time.Second})
+func (s *Server) InjectFault(endpoint Endpoint, fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults[endpoint] = append(s.faults[endpoint], &fault)
+}
+
+// ClearFaults 清除全部已注入的故障
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = map[Endpoint][]*Fault{}
+}
+
+// takeFault 取出端点当前生效的故障，次数用尽的故障被移除
+func (s *Server) takeFault(endpoint Endpoint) *Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	faults := s.faults[endpoint]
+	if len(faults) == 0 {
+		return nil
+	}
+	fault := *faults[0]
+	if faults[0].Times > 0 {
+		faults[0].Times--
+		if faults[0].Times == 0 {
+			s.faults[endpoint] = faults[1:]
+		}
+	}
+	return &fault
+}
+
+// withFaults 在处理请求前应用端点的故障
+func (s *Server) withFaults(endpoint Endpoint, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		fault := s.takeFault(endpoint)
+		if fault == nil {
+			next(w, r)
+			return
+		}
+
+		if fault.Latency > 0 {
+			timer := time.NewTimer(fault.Latency)
+			select {
+			case <-timer.C:
+			case <-r.Context().Done():
+				timer.Stop()
+				return
+			}
+		}
+		if fault.Status == 0 {
+			next(w, r)
+			return
+		}
+		writeProblem(w, fault.Status, fault.Code, fault.Detail)
+	}
+}
@@ -0,0 +1,86 @@
+package goauthtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/3086953492/goauthsdk/internal/httpx"
+)
+
+// Recorder 是录制请求/响应的 HTTPDoer，可传给 goauthsdk.WithHTTPClient
+// 请求转发给 next，请求与响应在脱敏后保存，调用 Save 写入录制文件
+//
+// 脱敏规则：
+//   - 请求头不录制（Authorization、DPoP、Cookie 等凭据不会写入文件）
+//   - 表单、query 与 JSON 中的 client_secret、code、token、access_token、refresh_token 等字段替换为 REDACTED
+//   - 任意位置的 JWT 替换为 REDACTED
+//   - 响应头只录制 Content-Type、WWW-Authenticate、Retry-After
+type Recorder struct {
+	path string
+	next httpx.HTTPDoer
+
+	mu           sync.Mutex
+	interactions []Interaction
+}
+
+// NewRecorder 创建录制器，next 为 nil 时使用 http.DefaultClient
+//
+// 示例用法:
+//
+//	rec := goauthtest.NewRecorder("testdata/client_credentials.json", nil)
+//	client, _ := goauthsdk.NewClient(frontend, backend, id, secret, redirect, goauthsdk.WithHTTPClient(rec))
+//	_, err := client.ClientCredentialsToken(ctx, "profile")
+//	// ...
+//	if err := rec.Save(); err != nil {
+//	    t.Fatal(err)
+//	}
+func NewRecorder(path string, next httpx.HTTPDoer) *Recorder {
+	if next == nil {
+		next = http.DefaultClient
+	}
+	return &Recorder{path: path, next: next}
+}
+
+// Do 转发请求并录制请求/响应；发送失败的请求不录制
+func (r *Recorder) Do(req *http.Request) (*http.Response, error) {
+	recordedReq, err := recordRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.next.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	recordedResp, err := recordResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.interactions = append(r.interactions, Interaction{Request: recordedReq, Response: recordedResp})
+	return resp, nil
+}
+
+// Save 将已录制的请求/响应写入录制文件（覆盖已有文件），必要时创建目录
+func (r *Recorder) Save() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data, err := json.MarshalIndent(fixtureFile{Interactions: r.interactions}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode fixture: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("create fixture dir: %w", err)
+	}
+	if err := os.WriteFile(r.path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write fixture: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package goauthtest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// ErrUnmatchedRequest 表示回放时请求没有匹配的录制记录
+var ErrUnmatchedRequest = errors.New("goauthtest: unmatched request")
+
+// Replayer 是回放录制文件的 HTTPDoer，可传给 goauthsdk.WithHTTPClient，不访问网络
+// 请求按 Method、Path 与规范化（排序、脱敏）后的表单或 JSON 请求体匹配，忽略 host 与请求头；
+// 相同请求按录制顺序依次回放，每条记录只使用一次
+type Replayer struct {
+	path string
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+	unmatched    []string
+}
+
+// NewReplayer 读取录制文件并创建回放器
+//
+// 示例用法:
+//
+//	rep, err := goauthtest.NewReplayer("testdata/client_credentials.json")
+//	if err != nil {
+//	    t.Fatal(err)
+//	}
+//	client, _ := goauthsdk.NewClient(frontend, backend, id, secret, redirect, goauthsdk.WithHTTPClient(rep))
+//	_, err = client.ClientCredentialsToken(ctx, "profile")
+//	// ...
+//	if err := rep.Verify(); err != nil {
+//	    t.Fatal(err)
+//	}
+func NewReplayer(path string) (*Replayer, error) {
+	f, err := loadFixture(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Replayer{
+		path:         path,
+		interactions: f.Interactions,
+		used:         make([]bool, len(f.Interactions)),
+	}, nil
+}
+
+// Do 返回第一条未使用且匹配的录制响应
+// 没有匹配的记录时返回 ErrUnmatchedRequest，错误信息包含请求与可用的录制记录，并在 Verify 中再次报告
+func (r *Replayer) Do(req *http.Request) (*http.Response, error) {
+	recorded, err := recordRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.interactions {
+		if r.used[i] || !requestMatches(interaction.Request, recorded) {
+			continue
+		}
+		r.used[i] = true
+		return interaction.Response.toResponse(req), nil
+	}
+
+	r.unmatched = append(r.unmatched, describeRequest(recorded))
+	return nil, fmt.Errorf("%w in %s: %s\nunused interactions:\n%s",
+		ErrUnmatchedRequest, r.path, describeRequest(recorded), r.describeUnused())
+}
+
+// Verify 检查回放是否完整：存在未匹配的请求或未使用的录制记录时返回错误
+func (r *Replayer) Verify() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var problems []string
+	for _, req := range r.unmatched {
+		problems = append(problems, "unmatched request: "+req)
+	}
+	for i, interaction := range r.interactions {
+		if !r.used[i] {
+			problems = append(problems, "unused interaction: "+describeRequest(interaction.Request))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("goauthtest: replay of %s incomplete:\n  %s", r.path, strings.Join(problems, "\n  "))
+	}
+	return nil
+}
+
+// describeUnused 列出未使用的录制记录（调用方持有 r.mu）
+func (r *Replayer) describeUnused() string {
+	var lines []string
+	for i, interaction := range r.interactions {
+		if !r.used[i] {
+			lines = append(lines, "  "+describeRequest(interaction.Request))
+		}
+	}
+	if len(lines) == 0 {
+		return "  (none)"
+	}
+	return strings.Join(lines, "\n")
+}
+
+// requestMatches 按 Method、Path 与规范化后的表单请求体匹配
+func requestMatches(recorded, req RecordedRequest) bool {
+	return recorded.Method == req.Method && recorded.Path == req.Path && recorded.Form == req.Form
+}
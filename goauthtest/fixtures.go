@@ -0,0 +1,253 @@
+package goauthtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// redacted 是脱敏后的占位值
+const redacted = "REDACTED"
+
+// Interaction 是录制的一次请求/响应
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest 是脱敏后的请求，回放时按 Method、Path、Form 匹配
+type RecordedRequest struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Query  string `json:"query,omitempty"` // 脱敏并排序后的 query，仅用于阅读，不参与匹配
+	Form   string `json:"form,omitempty"`  // 脱敏并规范化后的请求体：表单按参数名排序，JSON 按字段名排序；其余请求体仅脱敏 JWT
+}
+
+// RecordedResponse 是脱敏后的响应
+type RecordedResponse struct {
+	Status int               `json:"status"`
+	Header map[string]string `json:"header,omitempty"`
+	JSON   json.RawMessage   `json:"json,omitempty"` // JSON 响应体
+	Body   string            `json:"body,omitempty"` // 非 JSON 响应体
+}
+
+// fixtureFile 是录制文件的格式
+type fixtureFile struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// sensitiveParams 是需要脱敏的表单/query 参数与 JSON 请求体字段
+// 例如动态客户端注册的更新请求以 JSON 发送 client_secret
+var sensitiveParams = map[string]bool{
+	"client_secret":             true,
+	"client_assertion":          true,
+	"assertion":                 true,
+	"code":                      true,
+	"code_verifier":             true,
+	"device_code":               true,
+	"token":                     true,
+	"access_token":              true,
+	"refresh_token":             true,
+	"id_token":                  true,
+	"subject_token":             true,
+	"actor_token":               true,
+	"logout_token":              true,
+	"registration_access_token": true,
+	"password":                  true,
+}
+
+// sensitiveFields 是需要脱敏的 JSON 字段；响应中的 code 是业务错误码，不脱敏
+var sensitiveFields = map[string]bool{
+	"client_secret":             true,
+	"device_code":               true,
+	"access_token":              true,
+	"refresh_token":             true,
+	"id_token":                  true,
+	"registration_access_token": true,
+}
+
+// recordedHeaders 是录制的响应头，其余响应头（例如 Set-Cookie）不录制
+// DPoP-Nonce 需要录制，否则回放时客户端收不到 nonce 质询，无法按录制顺序重试
+var recordedHeaders = []string{"Content-Type", "WWW-Authenticate", "Retry-After", "DPoP-Nonce"}
+
+// jwtPattern 匹配 JWT 形式的字符串，出现在任意位置时均脱敏
+var jwtPattern = regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+
+// recordRequest 读取请求体（读取后恢复，可继续发送）并返回脱敏后的请求
+func recordRequest(req *http.Request) (RecordedRequest, error) {
+	recorded := RecordedRequest{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  scrubValues(req.URL.Query()).Encode(),
+	}
+	if req.Body == nil || req.Body == http.NoBody {
+		return recorded, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return RecordedRequest{}, fmt.Errorf("read request body: %w", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	recorded.Form, err = normalizeBody(req.Header.Get("Content-Type"), body)
+	if err != nil {
+		return RecordedRequest{}, err
+	}
+	return recorded, nil
+}
+
+// normalizeBody 规范化请求体：表单按参数名排序并脱敏，JSON 按字段名脱敏后重新编码（字段有序），其余请求体仅脱敏 JWT
+func normalizeBody(contentType string, body []byte) (string, error) {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case mediaType == "application/x-www-form-urlencoded":
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return "", fmt.Errorf("parse form body: %w", err)
+		}
+		return scrubValues(values).Encode(), nil
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		data, err := decodeJSON(body)
+		if err != nil {
+			return "", fmt.Errorf("parse json body: %w", err)
+		}
+		encoded, err := json.Marshal(scrubJSON(data, sensitiveParams))
+		if err != nil {
+			return "", fmt.Errorf("encode json body: %w", err)
+		}
+		return string(encoded), nil
+	default:
+		return jwtPattern.ReplaceAllString(string(body), redacted), nil
+	}
+}
+
+// scrubValues 脱敏表单/query 参数
+func scrubValues(values url.Values) url.Values {
+	for key, vs := range values {
+		for i, v := range vs {
+			if sensitiveParams[key] {
+				vs[i] = redacted
+			} else {
+				vs[i] = jwtPattern.ReplaceAllString(v, redacted)
+			}
+		}
+	}
+	return values
+}
+
+// recordResponse 读取响应体（读取后恢复，调用方可继续读取）并返回脱敏后的响应
+func recordResponse(resp *http.Response) (RecordedResponse, error) {
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return RecordedResponse{}, fmt.Errorf("read response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	recorded := RecordedResponse{Status: resp.StatusCode, Header: map[string]string{}}
+	for _, key := range recordedHeaders {
+		if v := resp.Header.Get(key); v != "" {
+			recorded.Header[key] = jwtPattern.ReplaceAllString(v, redacted)
+		}
+	}
+
+	data, err := decodeJSON(body)
+	if err != nil {
+		recorded.Body = jwtPattern.ReplaceAllString(string(body), redacted)
+		return recorded, nil
+	}
+	recorded.JSON, err = json.Marshal(scrubJSON(data, sensitiveFields))
+	if err != nil {
+		return RecordedResponse{}, fmt.Errorf("encode response body: %w", err)
+	}
+	return recorded, nil
+}
+
+// decodeJSON 解码 JSON 值，数字保持原文，避免重新编码时改变精度
+func decodeJSON(body []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var data any
+	if err := decoder.Decode(&data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// scrubJSON 递归脱敏 JSON：fields 中字段的字符串值与任意位置的 JWT 均替换为占位值
+func scrubJSON(v any, fields map[string]bool) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if s, ok := value.(string); ok && fields[key] && s != "" {
+				v[key] = redacted
+				continue
+			}
+			v[key] = scrubJSON(value, fields)
+		}
+		return v
+	case []any:
+		for i, value := range v {
+			v[i] = scrubJSON(value, fields)
+		}
+		return v
+	case string:
+		return jwtPattern.ReplaceAllString(v, redacted)
+	default:
+		return v
+	}
+}
+
+// toResponse 将录制的响应还原为 *http.Response
+func (r RecordedResponse) toResponse(req *http.Request) *http.Response {
+	body := []byte(r.Body)
+	if len(r.JSON) > 0 {
+		body = r.JSON
+	}
+
+	header := http.Header{}
+	for key, v := range r.Header {
+		header.Set(key, v)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.Status, http.StatusText(r.Status)),
+		StatusCode:    r.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// loadFixture 读取录制文件
+func loadFixture(path string) (*fixtureFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read fixture: %w", err)
+	}
+	var f fixtureFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("parse fixture %s: %w", path, err)
+	}
+	return &f, nil
+}
+
+// describeRequest 返回请求的简短描述，用于错误信息
+func describeRequest(r RecordedRequest) string {
+	if r.Form == "" {
+		return r.Method + " " + r.Path
+	}
+	return r.Method + " " + r.Path + " " + r.Form
+}
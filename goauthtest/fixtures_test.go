@@ -0,0 +1,194 @@
+package goauthtest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/3086953492/goauthsdk"
+	"github.com/3086953492/goauthsdk/internal/httpx"
+	"github.com/3086953492/goauthsdk/internal/jwtx"
+)
+
+func TestRecorderScrubsJSONRequestBody(t *testing.T) {
+	const (
+		clientSecret            = "registered-client-secret"
+		registrationAccessToken = "registration-access-token"
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode request body: %v", err)
+		}
+		if body["client_secret"] != clientSecret {
+			t.Errorf("client_secret = %v, want original secret sent upstream", body["client_secret"])
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"code":    0,
+			"message": "success",
+			"data": map[string]any{
+				"client_id":                 "client-1",
+				"client_name":               body["client_name"],
+				"redirect_uris":             body["redirect_uris"],
+				"client_secret":             clientSecret,
+				"registration_access_token": registrationAccessToken,
+				"registration_client_uri":   "http://" + r.Host + r.URL.Path,
+			},
+		})
+	}))
+	defer srv.Close()
+
+	reg := &goauthsdk.ClientRegistration{
+		ClientMetadata: goauthsdk.ClientMetadata{
+			ClientName:   "tenant-a portal",
+			RedirectURIs: []string{"https://tenant-a.example.com/callback"},
+		},
+		ClientID:                "client-1",
+		ClientSecret:            clientSecret,
+		RegistrationAccessToken: registrationAccessToken,
+		RegistrationClientURI:   srv.URL + "/api/v1/oauth/register/client-1",
+	}
+	fixture := filepath.Join(t.TempDir(), "update_client.json")
+
+	rec := NewRecorder(fixture, nil)
+	registration, err := goauthsdk.NewRegistrationClient(srv.URL, goauthsdk.WithHTTPClient(rec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := registration.UpdateClient(context.Background(), reg); err != nil {
+		t.Fatalf("UpdateClient: %v", err)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(fixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{clientSecret, registrationAccessToken} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("fixture contains %q:\n%s", secret, data)
+		}
+	}
+
+	// 回放时重新编码的请求体与录制的规范化请求体一致
+	rep, err := NewReplayer(fixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	registration, err = goauthsdk.NewRegistrationClient(srv.URL, goauthsdk.WithHTTPClient(rep))
+	if err != nil {
+		t.Fatal(err)
+	}
+	updated, err := registration.UpdateClient(context.Background(), reg)
+	if err != nil {
+		t.Fatalf("replay UpdateClient: %v", err)
+	}
+	if updated.ClientName != "tenant-a portal" {
+		t.Errorf("client_name = %q", updated.ClientName)
+	}
+	if err := rep.Verify(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRecorderReplaysDPoPNonceChallenge(t *testing.T) {
+	const nonce = "server-nonce-1"
+
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		var proof struct {
+			Nonce string `json:"nonce"`
+		}
+		if err := jwtx.DecodePayload(r.Header.Get("DPoP"), &proof); err != nil {
+			t.Errorf("decode dpop proof: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if proof.Nonce != nonce {
+			w.Header().Set("DPoP-Nonce", nonce)
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]any{"error": "use_dpop_nonce"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"code":    0,
+			"message": "success",
+			"data": map[string]any{
+				"access_token": "dpop-bound-access-token",
+				"expires_in":   3600,
+				"token_type":   "DPoP",
+			},
+		})
+	}))
+	defer srv.Close()
+
+	newClient := func(doer httpx.HTTPDoer) *goauthsdk.Client {
+		t.Helper()
+		key, err := goauthsdk.NewDPoPKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		client, err := goauthsdk.NewClient("https://portal.example.com", srv.URL, "client-1", "client-secret",
+			"https://portal.example.com/callback", goauthsdk.WithHTTPClient(doer), goauthsdk.WithDPoP(key))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return client
+	}
+	fixture := filepath.Join(t.TempDir(), "dpop_nonce.json")
+
+	rec := NewRecorder(fixture, nil)
+	if _, err := newClient(rec).ClientCredentialsToken(context.Background(), ""); err != nil {
+		t.Fatalf("ClientCredentialsToken: %v", err)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Fatalf("server calls = %d, want challenge and retry", calls)
+	}
+
+	// 回放时客户端从录制的 DPoP-Nonce 得到 nonce 并重试，消费全部交互
+	rep, err := NewReplayer(fixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := newClient(rep).ClientCredentialsToken(context.Background(), "")
+	if err != nil {
+		t.Fatalf("replay ClientCredentialsToken: %v", err)
+	}
+	if token.ExpiresIn != 3600 {
+		t.Errorf("expires_in = %d", token.ExpiresIn)
+	}
+	if err := rep.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Errorf("server calls during replay = %d", calls-2)
+	}
+}
+
+func TestNormalizeJSONBodyIsCanonical(t *testing.T) {
+	a, err := normalizeBody("application/json", []byte(`{"b":1,"client_secret":"s1","a":{"assertion":"x","n":12345678901234567890}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := normalizeBody("application/json; charset=utf-8", []byte(`{"a":{"n":12345678901234567890,"assertion":"y"},"client_secret":"s2","b":1}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"a":{"assertion":"REDACTED","n":12345678901234567890},"b":1,"client_secret":"REDACTED"}`
+	if a != want || b != want {
+		t.Errorf("normalized bodies:\n%s\n%s\nwant %s", a, b, want)
+	}
+}
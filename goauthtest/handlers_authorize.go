@@ -0,0 +1,134 @@
+package goauthtest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+)
+
+// authorizationCodeTTL 是授权码有效期
+const authorizationCodeTTL = time.Minute
+
+// handleAuthorize 模拟前端授权页：以当前登录用户自动同意授权，并重定向回 redirect_uri
+// client_id 或 redirect_uri 无效时不重定向，直接返回 400（RFC 6749 4.1.2.1）
+func (s *Server) handleAuthorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	client, ok := s.clients[q.Get("client_id")]
+	if !ok {
+		writeProblem(w, http.StatusBadRequest, "invalid_client", "unknown client_id")
+		return
+	}
+	redirectURI := q.Get("redirect_uri")
+	if !client.allowsRedirect(redirectURI) {
+		writeProblem(w, http.StatusBadRequest, "invalid_request", "redirect_uri is not registered")
+		return
+	}
+
+	redirect := func(params url.Values) {
+		u, _ := url.Parse(redirectURI)
+		values := u.Query()
+		for key, v := range params {
+			values[key] = v
+		}
+		if state := q.Get("state"); state != "" {
+			values.Set("state", state)
+		}
+		values.Set("iss", s.cfg.issuer)
+		u.RawQuery = values.Encode()
+		http.Redirect(w, r, u.String(), http.StatusFound)
+	}
+	redirectError := func(code, description string) {
+		redirect(url.Values{"error": {code}, "error_description": {description}})
+	}
+
+	if q.Get("response_type") != "code" {
+		redirectError("unsupported_response_type", "only response_type=code is supported")
+		return
+	}
+	challenge, method := q.Get("code_challenge"), q.Get("code_challenge_method")
+	if challenge != "" && method != "S256" {
+		redirectError("invalid_request", "code_challenge_method must be S256")
+		return
+	}
+	if client.secret == "" && challenge == "" {
+		redirectError("invalid_request", "public clients must use pkce")
+		return
+	}
+	if s.denyConsent {
+		redirectError("access_denied", "the user denied the request")
+		return
+	}
+
+	code := randomToken()
+	s.codes[code] = &authorizationCode{
+		clientID:      client.id,
+		redirectURI:   redirectURI,
+		scope:         q.Get("scope"),
+		subject:       s.loginSubject,
+		codeChallenge: challenge,
+		expiresAt:     time.Now().Add(authorizationCodeTTL),
+	}
+	redirect(url.Values{"code": {code}})
+}
+
+// allowsRedirect 判断回调地址是否已注册；loopback 地址匹配时忽略端口（RFC 8252 7.3）
+func (c *registeredClient) allowsRedirect(redirectURI string) bool {
+	if slices.Contains(c.redirectURIs, redirectURI) {
+		return true
+	}
+	r, err := url.Parse(redirectURI)
+	if err != nil || r.Scheme != "http" || (r.Hostname() != "127.0.0.1" && r.Hostname() != "::1") {
+		return false
+	}
+	for _, registered := range c.redirectURIs {
+		u, err := url.Parse(registered)
+		if err == nil && u.Scheme == r.Scheme && u.Hostname() == r.Hostname() && u.Path == r.Path {
+			return true
+		}
+	}
+	return false
+}
+
+// Authorize 访问授权地址并返回回调参数，模拟用户在浏览器中完成授权
+// 不会访问 redirect_uri，返回值可直接传给 Client.ParseAuthorizationResponse
+//
+// 示例用法:
+//
+//	authURL, _ := client.BuildAuthorizationURL(state, "profile")
+//	values, err := srv.Authorize(ctx, authURL)
+//	resp, err := client.ParseAuthorizationResponse(values, state)
+func (s *Server) Authorize(ctx context.Context, authURL string) (url.Values, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, authURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create authorize request: %w", err)
+	}
+
+	// 复制一份客户端，避免修改 httptest.Server 共享的 Client
+	httpClient := *s.HTTPClient()
+	httpClient.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("send authorize request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusFound {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("authorize request failed: status %d: %s", resp.StatusCode, body)
+	}
+	location, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		return nil, fmt.Errorf("parse redirect location: %w", err)
+	}
+	return location.Query(), nil
+}
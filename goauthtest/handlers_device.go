@@ -0,0 +1,120 @@
+package goauthtest
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/3086953492/goauthsdk"
+)
+
+// 设备授权参数；轮询间隔取最小值 1 秒，避免拖慢测试
+const (
+	deviceCodeTTL      = 10 * time.Minute
+	devicePollInterval = 1
+)
+
+// deviceGrant 是一次设备授权（RFC 8628）
+type deviceGrant struct {
+	clientID  string
+	scope     string
+	userCode  string
+	subject   string
+	approved  bool
+	denied    bool
+	expiresAt time.Time
+}
+
+// ApproveDevice 以当前登录用户批准用户码对应的设备授权
+// 批准后 PollDeviceToken 的下一次轮询即可获得令牌；用户码不存在时返回 false
+func (s *Server) ApproveDevice(userCode string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	grant := s.findDevice(userCode)
+	if grant == nil {
+		return false
+	}
+	grant.approved, grant.subject = true, s.loginSubject
+	return true
+}
+
+// DenyDevice 拒绝用户码对应的设备授权，轮询将返回 access_denied；用户码不存在时返回 false
+func (s *Server) DenyDevice(userCode string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	grant := s.findDevice(userCode)
+	if grant == nil {
+		return false
+	}
+	grant.denied = true
+	return true
+}
+
+// findDevice 按用户码查找设备授权（调用方持有 s.mu）
+func (s *Server) findDevice(userCode string) *deviceGrant {
+	for _, grant := range s.devices {
+		if grant.userCode == userCode {
+			return grant
+		}
+	}
+	return nil
+}
+
+// handleDeviceAuthorization 处理设备授权请求，返回设备码与用户码
+func (s *Server) handleDeviceAuthorization(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeProblem(w, http.StatusBadRequest, "invalid_request", "malformed form body")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	client := s.authenticateClient(w, r)
+	if client == nil {
+		return
+	}
+
+	deviceCode := randomToken()
+	userCode := strings.ToUpper(randomToken()[:8])
+	s.devices[deviceCode] = &deviceGrant{
+		clientID:  client.id,
+		scope:     r.PostForm.Get("scope"),
+		userCode:  userCode,
+		expiresAt: time.Now().Add(deviceCodeTTL),
+	}
+
+	verificationURI := s.URL + "/device"
+	writeData(w, goauthsdk.DeviceAuthorizationResponse{
+		DeviceCode:              deviceCode,
+		UserCode:                userCode,
+		VerificationURI:         verificationURI,
+		VerificationURIComplete: fmt.Sprintf("%s?user_code=%s", verificationURI, userCode),
+		ExpiresIn:               int(deviceCodeTTL / time.Second),
+		Interval:                devicePollInterval,
+	})
+}
+
+// grantDeviceCode 使用设备码轮询令牌；批准前返回 authorization_pending
+func (s *Server) grantDeviceCode(w http.ResponseWriter, r *http.Request, client *registeredClient) {
+	deviceCode := r.PostForm.Get("device_code")
+	grant, ok := s.devices[deviceCode]
+	switch {
+	case !ok || grant.clientID != client.id:
+		writeProblem(w, http.StatusBadRequest, "invalid_grant", "device_code is invalid")
+	case time.Now().After(grant.expiresAt):
+		delete(s.devices, deviceCode)
+		writeProblem(w, http.StatusBadRequest, "expired_token", "device_code has expired")
+	case grant.denied:
+		delete(s.devices, deviceCode)
+		writeProblem(w, http.StatusBadRequest, "access_denied", "the user denied the request")
+	case !grant.approved:
+		writeProblem(w, http.StatusBadRequest, "authorization_pending", "the user has not yet approved the request")
+	default:
+		delete(s.devices, deviceCode)
+		writeData(w, s.issueTokens(client.id, grant.subject, grant.scope))
+	}
+}
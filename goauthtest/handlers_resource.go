@@ -0,0 +1,148 @@
+package goauthtest
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/3086953492/goauthsdk"
+)
+
+// handleIntrospect 处理令牌内省（RFC 7662）；无效、过期或已撤销的令牌返回 active=false
+func (s *Server) handleIntrospect(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeProblem(w, http.StatusBadRequest, "invalid_request", "malformed form body")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.authenticateClient(w, r) == nil {
+		return
+	}
+
+	token := r.PostForm.Get("token")
+	claims, err := s.parseToken(token, "access")
+	if err != nil {
+		if _, ok := s.refreshTokens[token]; ok {
+			claims, err = s.parseToken(token, "refresh")
+		}
+	}
+	if err != nil {
+		writeData(w, goauthsdk.IntrospectionResponse{Active: false})
+		return
+	}
+
+	resp := goauthsdk.IntrospectionResponse{
+		Active:    true,
+		Scope:     claims.scope(),
+		ClientID:  claims.clientID(),
+		TokenType: "Bearer",
+		Exp:       claims.ExpiresAt.Unix(),
+		ACR:       claims.ACR,
+		AMR:       claims.AMR,
+		AuthTime:  claims.AuthTime,
+	}
+	if user, ok := s.users[claims.Subject]; ok {
+		resp.Sub, resp.Username = user.Subject, user.Username
+	}
+	writeData(w, resp)
+}
+
+// handleRevoke 处理令牌撤销（RFC 7009）；未知或无效的令牌同样返回 200
+func (s *Server) handleRevoke(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeProblem(w, http.StatusBadRequest, "invalid_request", "malformed form body")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.authenticateClient(w, r) == nil {
+		return
+	}
+
+	token := r.PostForm.Get("token")
+	delete(s.refreshTokens, token)
+	for _, tokenType := range []string{"access", "refresh"} {
+		if claims, err := s.parseToken(token, tokenType); err == nil {
+			s.revoked[claims.ID] = true
+		}
+	}
+	writeData(w, nil)
+}
+
+// authenticateBearer 校验 Authorization: Bearer 访问令牌
+// 失败时写出 401 INVALID_TOKEN 并返回 nil（调用方持有 s.mu）
+func (s *Server) authenticateBearer(w http.ResponseWriter, r *http.Request) *tokenClaims {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		writeBearerProblem(w, http.StatusUnauthorized, "INVALID_TOKEN", "missing bearer token")
+		return nil
+	}
+	claims, err := s.parseToken(token, "access")
+	if err != nil {
+		writeBearerProblem(w, http.StatusUnauthorized, "INVALID_TOKEN", "access token is invalid, expired or revoked")
+		return nil
+	}
+	return claims
+}
+
+// handleUserInfo 返回访问令牌对应用户的信息；客户端凭证令牌没有用户上下文，返回 403
+func (s *Server) handleUserInfo(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	claims := s.authenticateBearer(w, r)
+	if claims == nil {
+		return
+	}
+	user, ok := s.users[claims.Subject]
+	if !ok {
+		writeBearerProblem(w, http.StatusForbidden, "INSUFFICIENT_SCOPE", "token has no user context")
+		return
+	}
+
+	writeData(w, goauthsdk.UserInfo{
+		Sub:       user.Subject,
+		Nickname:  user.Nickname,
+		Picture:   user.Avatar,
+		UpdatedAt: time.Now().Unix(),
+	})
+}
+
+// handleGetUser 按 subject 查询用户详情，访问令牌需包含 profile scope
+func (s *Server) handleGetUser(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	claims := s.authenticateBearer(w, r)
+	if claims == nil {
+		return
+	}
+	if !slices.Contains(strings.Fields(claims.scope()), "profile") {
+		writeBearerProblem(w, http.StatusForbidden, "INSUFFICIENT_SCOPE", "profile scope is required")
+		return
+	}
+	user, ok := s.users[r.PathValue("sub")]
+	if !ok {
+		writeProblem(w, http.StatusNotFound, "USER_NOT_FOUND", "user not found")
+		return
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	writeData(w, goauthsdk.UserDetail{
+		ID:        user.ID,
+		Subject:   user.Subject,
+		Username:  user.Username,
+		Nickname:  user.Nickname,
+		Avatar:    user.Avatar,
+		Status:    user.Status,
+		Role:      user.Role,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+}
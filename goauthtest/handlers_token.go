@@ -0,0 +1,211 @@
+package goauthtest
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/3086953492/goauthsdk"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// 授权类型（grant_type）
+const (
+	grantAuthorizationCode = "authorization_code"
+	grantRefreshToken      = "refresh_token"
+	grantClientCredentials = "client_credentials"
+	grantDeviceCode        = "urn:ietf:params:oauth:grant-type:device_code"
+	grantTokenExchange     = "urn:ietf:params:oauth:grant-type:token-exchange"
+	grantJWTBearer         = "urn:ietf:params:oauth:grant-type:jwt-bearer"
+)
+
+// authenticateClient 校验客户端认证：client_secret_basic、client_secret_post，公开客户端只需 client_id
+// 失败时写出 401 invalid_client 并返回 nil（调用方持有 s.mu）
+func (s *Server) authenticateClient(w http.ResponseWriter, r *http.Request) *registeredClient {
+	clientID, secret, basic := r.BasicAuth()
+	if !basic {
+		clientID, secret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+
+	client, ok := s.clients[clientID]
+	if !ok || subtle.ConstantTimeCompare([]byte(client.secret), []byte(secret)) != 1 {
+		w.Header().Set("WWW-Authenticate", `Basic realm="goauth"`)
+		writeProblem(w, http.StatusUnauthorized, "invalid_client", "client authentication failed")
+		return nil
+	}
+	return client
+}
+
+// handleToken 处理令牌请求，按 grant_type 分发
+func (s *Server) handleToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeProblem(w, http.StatusBadRequest, "invalid_request", "malformed form body")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	client := s.authenticateClient(w, r)
+	if client == nil {
+		return
+	}
+
+	switch r.PostForm.Get("grant_type") {
+	case grantAuthorizationCode:
+		s.grantAuthorizationCode(w, r, client)
+	case grantRefreshToken:
+		s.grantRefreshToken(w, r, client)
+	case grantClientCredentials:
+		s.grantClientCredentials(w, r, client)
+	case grantDeviceCode:
+		s.grantDeviceCode(w, r, client)
+	case grantTokenExchange:
+		s.grantTokenExchange(w, r, client)
+	case grantJWTBearer:
+		s.grantJWTBearer(w, r, client)
+	default:
+		writeProblem(w, http.StatusBadRequest, "unsupported_grant_type", "unsupported grant_type")
+	}
+}
+
+// grantAuthorizationCode 使用授权码换取令牌；授权码只能使用一次
+func (s *Server) grantAuthorizationCode(w http.ResponseWriter, r *http.Request, client *registeredClient) {
+	code, ok := s.codes[r.PostForm.Get("code")]
+	delete(s.codes, r.PostForm.Get("code"))
+	if !ok || code.clientID != client.id || time.Now().After(code.expiresAt) {
+		writeProblem(w, http.StatusBadRequest, "invalid_grant", "authorization code is invalid or expired")
+		return
+	}
+	if r.PostForm.Get("redirect_uri") != code.redirectURI {
+		writeProblem(w, http.StatusBadRequest, "invalid_grant", "redirect_uri does not match")
+		return
+	}
+	if code.codeChallenge != "" {
+		sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+		if base64.RawURLEncoding.EncodeToString(sum[:]) != code.codeChallenge {
+			writeProblem(w, http.StatusBadRequest, "invalid_grant", "code_verifier does not match")
+			return
+		}
+	}
+
+	writeData(w, s.issueTokens(client.id, code.subject, code.scope))
+}
+
+// grantRefreshToken 使用刷新令牌换取新令牌；刷新令牌轮换，旧令牌立即失效
+func (s *Server) grantRefreshToken(w http.ResponseWriter, r *http.Request, client *registeredClient) {
+	raw := r.PostForm.Get("refresh_token")
+	issued, ok := s.refreshTokens[raw]
+	claims, err := s.parseToken(raw, "refresh")
+	if !ok || err != nil || issued.clientID != client.id {
+		writeProblem(w, http.StatusBadRequest, "invalid_grant", "refresh token is invalid, expired or revoked")
+		return
+	}
+
+	scope := issued.scope
+	if requested := r.PostForm.Get("scope"); requested != "" {
+		if !scopeSubset(requested, issued.scope) {
+			writeProblem(w, http.StatusBadRequest, "invalid_scope", "requested scope exceeds the original grant")
+			return
+		}
+		scope = requested
+	}
+
+	delete(s.refreshTokens, raw)
+	s.revoked[claims.ID] = true
+	writeData(w, s.issueTokens(client.id, issued.subject, scope))
+}
+
+// grantClientCredentials 签发客户端凭证令牌，sub 为 client:<client_id>，不签发刷新令牌
+func (s *Server) grantClientCredentials(w http.ResponseWriter, r *http.Request, client *registeredClient) {
+	if client.secret == "" {
+		writeProblem(w, http.StatusBadRequest, "unauthorized_client", "public clients cannot use client_credentials")
+		return
+	}
+
+	ttl := s.cfg.accessTokenTTL
+	if s.expireTokens {
+		ttl = 0
+	}
+	scope := r.PostForm.Get("scope")
+	writeData(w, goauthsdk.ClientCredentialsTokenResponse{
+		AccessToken: s.signToken("access", client.id, "client:"+client.id, scope, ttl),
+		ExpiresIn:   int(ttl / time.Second),
+		TokenType:   "Bearer",
+		Scope:       scope,
+	})
+}
+
+// grantTokenExchange 以有效访问令牌换取同一主体的新访问令牌（RFC 8693）
+func (s *Server) grantTokenExchange(w http.ResponseWriter, r *http.Request, client *registeredClient) {
+	subjectType := r.PostForm.Get("subject_token_type")
+	if subjectType != goauthsdk.TokenTypeAccessToken && subjectType != goauthsdk.TokenTypeJWT {
+		writeProblem(w, http.StatusBadRequest, "invalid_request", "unsupported subject_token_type")
+		return
+	}
+	subject, err := s.parseToken(r.PostForm.Get("subject_token"), "access")
+	if err != nil {
+		writeProblem(w, http.StatusBadRequest, "invalid_grant", "subject_token is invalid")
+		return
+	}
+
+	scope := subject.scope()
+	if requested := r.PostForm.Get("scope"); requested != "" {
+		if !scopeSubset(requested, scope) {
+			writeProblem(w, http.StatusBadRequest, "invalid_scope", "requested scope exceeds the subject token")
+			return
+		}
+		scope = requested
+	}
+
+	ttl := s.cfg.accessTokenTTL
+	if s.expireTokens {
+		ttl = 0
+	}
+	writeData(w, goauthsdk.TokenExchangeResponse{
+		AccessToken:     s.signToken("access", client.id, subject.Subject, scope, ttl),
+		IssuedTokenType: goauthsdk.TokenTypeAccessToken,
+		TokenType:       "Bearer",
+		ExpiresIn:       int(ttl / time.Second),
+		Scope:           scope,
+	})
+}
+
+// grantJWTBearer 使用 JWT 断言换取令牌（RFC 7523）
+// 断言需以客户端密钥 HS256 签名，iss 为 client_id，sub 为已存在的用户，aud 包含 Issuer
+func (s *Server) grantJWTBearer(w http.ResponseWriter, r *http.Request, client *registeredClient) {
+	var claims jwt.RegisteredClaims
+	_, err := jwt.ParseWithClaims(r.PostForm.Get("assertion"), &claims, func(*jwt.Token) (any, error) {
+		return []byte(client.secret), nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(client.id),
+		jwt.WithAudience(s.cfg.issuer),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil || client.secret == "" {
+		writeProblem(w, http.StatusBadRequest, "invalid_grant", "assertion is invalid")
+		return
+	}
+	if _, ok := s.users[claims.Subject]; !ok {
+		writeProblem(w, http.StatusBadRequest, "invalid_grant", "assertion subject is unknown")
+		return
+	}
+
+	writeData(w, s.issueTokens(client.id, claims.Subject, r.PostForm.Get("scope")))
+}
+
+// scopeSubset 判断 requested 中的每个 scope 都包含在 granted 中
+func scopeSubset(requested, granted string) bool {
+	grantedScopes := strings.Fields(granted)
+	for _, scope := range strings.Fields(requested) {
+		if !slices.Contains(grantedScopes, scope) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,52 @@
+package goauthtest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// writeData 写出成功响应：{ "code": 0, "message": "success", "data": {...} }
+func writeData(w http.ResponseWriter, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"code":    0,
+		"message": "success",
+		"data":    data,
+	})
+}
+
+// writeProblem 写出 RFC 7807 错误响应：{ "type", "title", "status", "code", "detail" }
+func writeProblem(w http.ResponseWriter, status int, code, detail string) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"type":   "about:blank",
+		"title":  problemTitle(status),
+		"status": status,
+		"code":   code,
+		"detail": detail,
+	})
+}
+
+// writeBearerProblem 写出资源端点的 401/403 错误，并附带 Bearer 质询（RFC 6750 3）
+func writeBearerProblem(w http.ResponseWriter, status int, code, detail string) {
+	oauthError := "invalid_token"
+	if status == http.StatusForbidden {
+		oauthError = "insufficient_scope"
+	}
+	w.Header().Set("WWW-Authenticate", `Bearer error="`+oauthError+`"`)
+	writeProblem(w, status, code, detail)
+}
+
+// problemTitle 返回状态码对应的标题，例如 UNAUTHORIZED、NOT_FOUND
+func problemTitle(status int) string {
+	text := http.StatusText(status)
+	if text == "" {
+		return "ERROR"
+	}
+	return strings.ToUpper(strings.ReplaceAll(text, " ", "_"))
+}
@@ -0,0 +1,291 @@
+// Package goauthtest 提供进程内的 goauth 模拟服务，用于在测试中驱动 goauthsdk.Client，无需启动真实的 goauth
+package goauthtest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/3086953492/goauthsdk"
+)
+
+// 模拟服务的默认配置
+const (
+	DefaultClientID           = "test-client"
+	DefaultClientSecret       = "test-client-secret"
+	DefaultRedirectURI        = "http://127.0.0.1/callback"
+	DefaultAccessTokenSecret  = "test-access-token-secret"
+	DefaultRefreshTokenSecret = "test-refresh-token-secret"
+	DefaultAccessTokenTTL     = time.Hour
+	DefaultRefreshTokenTTL    = 30 * 24 * time.Hour
+)
+
+// User 是模拟服务中的用户
+type User struct {
+	ID       uint64
+	Subject  string
+	Username string
+	Nickname string
+	Avatar   string
+	Role     string // user / admin
+	Status   int    // 1=正常，0=禁用
+}
+
+// DefaultUser 是未通过 WithUsers 配置用户时使用的用户，也是授权页默认登录的用户
+var DefaultUser = User{
+	ID:       1,
+	Subject:  "user-1",
+	Username: "alice",
+	Nickname: "Alice",
+	Avatar:   "https://example.com/avatar/alice.png",
+	Role:     "user",
+	Status:   1,
+}
+
+// registeredClient 是模拟服务中注册的客户端
+type registeredClient struct {
+	id           string
+	secret       string // 为空表示公开客户端（token_endpoint_auth_method=none）
+	redirectURIs []string
+}
+
+// Server 是基于 httptest 的 goauth 模拟服务
+// 前端授权页与后端 API 使用同一地址（URL），授权页自动同意授权并重定向回 redirect_uri
+//
+// 实现的端点：
+//   - GET  /oauth/authorize
+//   - POST /api/v1/oauth/token（authorization_code、refresh_token、client_credentials、device_code、token-exchange、jwt-bearer）
+//   - POST /api/v1/oauth/device_authorization
+//   - POST /api/v1/oauth/introspect
+//   - POST /api/v1/oauth/revoke
+//   - GET  /api/v1/oauth/userinfo
+//   - GET  /api/v1/users/sub/{sub}
+//
+// 成功响应使用 {code,message,data} 包装，错误响应使用 RFC 7807 problem+json；
+// 令牌为 HS256 JWT，可由使用相同密钥的 goauthsdk.JWTVerifier 离线验签
+type Server struct {
+	// URL 模拟服务地址，同时作为 FrontendBaseURL、BackendBaseURL 与 Issuer
+	URL string
+
+	httpServer *httptest.Server
+	cfg        serverConfig
+
+	mu            sync.Mutex
+	clients       map[string]*registeredClient
+	users         map[string]*User
+	loginSubject  string
+	codes         map[string]*authorizationCode
+	refreshTokens map[string]*issuedRefreshToken
+	revoked       map[string]bool
+	devices       map[string]*deviceGrant
+	faults        map[Endpoint][]*Fault
+	expireTokens  bool
+	denyConsent   bool
+}
+
+// serverConfig 是 Option 可修改的配置
+type serverConfig struct {
+	clients            []*registeredClient
+	users              []User
+	accessTokenSecret  string
+	refreshTokenSecret string
+	accessTokenTTL     time.Duration
+	refreshTokenTTL    time.Duration
+	issuer             string
+	tls                bool
+}
+
+// Option 用于配置模拟服务
+type Option func(*serverConfig)
+
+// WithClient 注册客户端；secret 为空时注册为公开客户端
+// 未调用时注册 DefaultClientID / DefaultClientSecret / DefaultRedirectURI；loopback 回调地址匹配时忽略端口
+func WithClient(clientID, secret string, redirectURIs ...string) Option {
+	return func(c *serverConfig) {
+		c.clients = append(c.clients, &registeredClient{id: clientID, secret: secret, redirectURIs: redirectURIs})
+	}
+}
+
+// WithUsers 设置模拟服务中的用户，第一个用户为授权页默认登录的用户
+func WithUsers(users ...User) Option {
+	return func(c *serverConfig) {
+		c.users = append(c.users, users...)
+	}
+}
+
+// WithJWTSecrets 设置访问令牌与刷新令牌的签名密钥
+func WithJWTSecrets(accessSecret, refreshSecret string) Option {
+	return func(c *serverConfig) {
+		c.accessTokenSecret = accessSecret
+		c.refreshTokenSecret = refreshSecret
+	}
+}
+
+// WithTokenTTL 设置访问令牌与刷新令牌的有效期
+func WithTokenTTL(accessTTL, refreshTTL time.Duration) Option {
+	return func(c *serverConfig) {
+		c.accessTokenTTL = accessTTL
+		c.refreshTokenTTL = refreshTTL
+	}
+}
+
+// WithIssuer 设置令牌的 iss 与授权回调中的 iss 参数，默认为服务地址
+func WithIssuer(issuer string) Option {
+	return func(c *serverConfig) {
+		c.issuer = issuer
+	}
+}
+
+// WithTLS 使用 httptest.NewTLSServer 启动服务；客户端需使用 HTTPClient() 返回的信任该证书的 HTTP 客户端
+func WithTLS() Option {
+	return func(c *serverConfig) {
+		c.tls = true
+	}
+}
+
+// NewServer 启动模拟服务，测试结束时调用 Close
+//
+// 示例用法:
+//
+//	srv := goauthtest.NewServer()
+//	defer srv.Close()
+//
+//	client, err := srv.Client()
+//	if err != nil {
+//	    t.Fatal(err)
+//	}
+//	token, err := client.ClientCredentialsToken(ctx, "profile")
+func NewServer(opts ...Option) *Server {
+	cfg := serverConfig{
+		accessTokenSecret:  DefaultAccessTokenSecret,
+		refreshTokenSecret: DefaultRefreshTokenSecret,
+		accessTokenTTL:     DefaultAccessTokenTTL,
+		refreshTokenTTL:    DefaultRefreshTokenTTL,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if len(cfg.clients) == 0 {
+		cfg.clients = []*registeredClient{{id: DefaultClientID, secret: DefaultClientSecret, redirectURIs: []string{DefaultRedirectURI}}}
+	}
+	if len(cfg.users) == 0 {
+		cfg.users = []User{DefaultUser}
+	}
+
+	s := &Server{
+		cfg:           cfg,
+		clients:       map[string]*registeredClient{},
+		users:         map[string]*User{},
+		loginSubject:  cfg.users[0].Subject,
+		codes:         map[string]*authorizationCode{},
+		refreshTokens: map[string]*issuedRefreshToken{},
+		revoked:       map[string]bool{},
+		devices:       map[string]*deviceGrant{},
+		faults:        map[Endpoint][]*Fault{},
+	}
+	for _, client := range cfg.clients {
+		s.clients[client.id] = client
+	}
+	for i := range cfg.users {
+		user := cfg.users[i]
+		s.users[user.Subject] = &user
+	}
+
+	if cfg.tls {
+		s.httpServer = httptest.NewTLSServer(s.routes())
+	} else {
+		s.httpServer = httptest.NewServer(s.routes())
+	}
+	s.URL = s.httpServer.URL
+	if s.cfg.issuer == "" {
+		s.cfg.issuer = s.URL
+	}
+	return s
+}
+
+// Close 关闭模拟服务
+func (s *Server) Close() {
+	s.httpServer.Close()
+}
+
+// HTTPClient 返回可访问模拟服务的 HTTP 客户端（WithTLS 时信任服务证书）
+func (s *Server) HTTPClient() *http.Client {
+	return s.httpServer.Client()
+}
+
+// Issuer 返回令牌的 iss 与授权回调中的 iss 参数
+func (s *Server) Issuer() string {
+	return s.cfg.issuer
+}
+
+// AccessTokenSecret 返回访问令牌签名密钥
+func (s *Server) AccessTokenSecret() string {
+	return s.cfg.accessTokenSecret
+}
+
+// RefreshTokenSecret 返回刷新令牌签名密钥
+func (s *Server) RefreshTokenSecret() string {
+	return s.cfg.refreshTokenSecret
+}
+
+// Client 创建指向模拟服务的 goauthsdk.Client
+// 使用第一个注册的客户端，已配置 HTTP 客户端、JWT 密钥与 Issuer；opts 在其后应用，可覆盖默认配置
+func (s *Server) Client(opts ...goauthsdk.ClientOption) (*goauthsdk.Client, error) {
+	client := s.cfg.clients[0]
+	redirectURI := DefaultRedirectURI
+	if len(client.redirectURIs) > 0 {
+		redirectURI = client.redirectURIs[0]
+	}
+
+	base := []goauthsdk.ClientOption{
+		goauthsdk.WithHTTPClient(s.HTTPClient()),
+		goauthsdk.WithJWTSecrets(s.cfg.accessTokenSecret, s.cfg.refreshTokenSecret),
+		goauthsdk.WithIssuer(s.cfg.issuer),
+	}
+	if client.secret == "" {
+		base = append(base, goauthsdk.WithClientAuthMethod(goauthsdk.ClientAuthNone))
+	}
+	return goauthsdk.NewClient(s.URL, s.URL, client.id, client.secret, redirectURI, append(base, opts...)...)
+}
+
+// SetLoginUser 设置授权页与设备授权自动登录的用户
+func (s *Server) SetLoginUser(subject string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.loginSubject = subject
+}
+
+// AddUser 添加或替换用户
+func (s *Server) AddUser(user User) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.users[user.Subject] = &user
+}
+
+// DenyConsent 设置授权页是否拒绝授权（重定向时返回 error=access_denied）
+func (s *Server) DenyConsent(deny bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.denyConsent = deny
+}
+
+// ExpireTokens 设置之后签发的访问令牌与刷新令牌是否已过期，用于测试过期处理
+func (s *Server) ExpireTokens(expired bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expireTokens = expired
+}
+
+// routes 注册全部端点
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /oauth/authorize", s.withFaults(EndpointAuthorize, s.handleAuthorize))
+	mux.HandleFunc("POST /api/v1/oauth/token", s.withFaults(EndpointToken, s.handleToken))
+	mux.HandleFunc("POST /api/v1/oauth/device_authorization", s.withFaults(EndpointDeviceAuthorization, s.handleDeviceAuthorization))
+	mux.HandleFunc("POST /api/v1/oauth/introspect", s.withFaults(EndpointIntrospect, s.handleIntrospect))
+	mux.HandleFunc("POST /api/v1/oauth/revoke", s.withFaults(EndpointRevoke, s.handleRevoke))
+	mux.HandleFunc("GET /api/v1/oauth/userinfo", s.withFaults(EndpointUserInfo, s.handleUserInfo))
+	mux.HandleFunc("GET /api/v1/users/sub/{sub}", s.withFaults(EndpointUsers, s.handleGetUser))
+	return mux
+}
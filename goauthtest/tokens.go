@@ -0,0 +1,157 @@
+package goauthtest
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/3086953492/goauthsdk"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// authorizationCode 是已签发、尚未使用的授权码
+type authorizationCode struct {
+	clientID      string
+	redirectURI   string
+	scope         string
+	subject       string
+	codeChallenge string
+	expiresAt     time.Time
+}
+
+// issuedRefreshToken 是有效的刷新令牌，使用后轮换
+type issuedRefreshToken struct {
+	clientID string
+	subject  string
+	scope    string
+}
+
+// tokenClaims 是模拟服务签发的 JWT 声明，与 goauth 访问令牌/刷新令牌格式一致
+type tokenClaims struct {
+	TokenType string         `json:"token_type"`
+	Extra     map[string]any `json:"extra,omitempty"`
+	ACR       string         `json:"acr,omitempty"`
+	AMR       []string       `json:"amr,omitempty"`
+	AuthTime  int64          `json:"auth_time,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// scope 返回 extra 中的 scope
+func (c *tokenClaims) scope() string {
+	scope, _ := c.Extra["scope"].(string)
+	return scope
+}
+
+// clientID 返回 extra 中的 client_id
+func (c *tokenClaims) clientID() string {
+	clientID, _ := c.Extra["client_id"].(string)
+	return clientID
+}
+
+// randomToken 生成 base64url 编码的随机串
+func randomToken() string {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("goauthtest: generate random token: %v", err))
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// MintAccessToken 签发访问令牌，ttl <= 0 时签发已过期的令牌
+// 令牌属于第一个注册的客户端，可直接用于 UserInfo、GetUser 等接口或离线验签
+func (s *Server) MintAccessToken(subject, scope string, ttl time.Duration) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.signToken("access", s.cfg.clients[0].id, subject, scope, ttl)
+}
+
+// signToken 使用对应类型的密钥签发 HS256 JWT（调用方持有 s.mu）
+func (s *Server) signToken(tokenType, clientID, subject, scope string, ttl time.Duration) string {
+	now := time.Now()
+	if ttl <= 0 {
+		// 已过期的令牌：签发时间与过期时间都在过去
+		now = now.Add(-time.Hour)
+		ttl = time.Minute
+	}
+
+	claims := tokenClaims{
+		TokenType: tokenType,
+		Extra:     map[string]any{"client_id": clientID, "scope": scope},
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    s.cfg.issuer,
+			Subject:   subject,
+			Audience:  jwt.ClaimStrings{clientID},
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+			ID:        randomToken(),
+		},
+	}
+	if _, ok := s.users[subject]; ok {
+		claims.ACR = "pwd"
+		claims.AMR = []string{"pwd"}
+		claims.AuthTime = now.Unix()
+	}
+
+	secret := s.cfg.accessTokenSecret
+	if tokenType == "refresh" {
+		secret = s.cfg.refreshTokenSecret
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	if err != nil {
+		panic(fmt.Sprintf("goauthtest: sign token: %v", err))
+	}
+	return token
+}
+
+// issueTokens 签发访问令牌与刷新令牌（调用方持有 s.mu）
+func (s *Server) issueTokens(clientID, subject, scope string) goauthsdk.TokenResponse {
+	accessTTL, refreshTTL := s.cfg.accessTokenTTL, s.cfg.refreshTokenTTL
+	if s.expireTokens {
+		accessTTL, refreshTTL = 0, 0
+	}
+
+	refreshToken := s.signToken("refresh", clientID, subject, scope, refreshTTL)
+	s.refreshTokens[refreshToken] = &issuedRefreshToken{clientID: clientID, subject: subject, scope: scope}
+
+	return goauthsdk.TokenResponse{
+		AccessToken: goauthsdk.AccessTokenInfo{
+			AccessToken: s.signToken("access", clientID, subject, scope, accessTTL),
+			ExpiresIn:   int(accessTTL / time.Second),
+		},
+		RefreshToken: goauthsdk.RefreshTokenInfo{
+			RefreshToken: refreshToken,
+			ExpiresIn:    int(refreshTTL / time.Second),
+		},
+		TokenType: "Bearer",
+		Scope:     scope,
+	}
+}
+
+// errTokenRevoked 表示令牌已被撤销
+var errTokenRevoked = errors.New("token has been revoked")
+
+// parseToken 校验令牌签名、类型、有效期与撤销状态（调用方持有 s.mu）
+func (s *Server) parseToken(token, tokenType string) (*tokenClaims, error) {
+	secret := s.cfg.accessTokenSecret
+	if tokenType == "refresh" {
+		secret = s.cfg.refreshTokenSecret
+	}
+
+	var claims tokenClaims
+	_, err := jwt.ParseWithClaims(token, &claims, func(*jwt.Token) (any, error) {
+		return []byte(secret), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil {
+		return nil, err
+	}
+	if claims.TokenType != tokenType {
+		return nil, fmt.Errorf("token is not a %s token", tokenType)
+	}
+	if s.revoked[claims.ID] {
+		return nil, errTokenRevoked
+	}
+	return &claims, nil
+}
@@ -1,6 +1,10 @@
 package configx
 
 import (
+	"crypto"
+	"crypto/tls"
+	"crypto/x509"
+
 	"github.com/3086953492/goauthsdk/internal/httpx"
 )
 
@@ -21,6 +25,9 @@ type Config struct {
 	// RedirectURI OAuth 回调地址
 	RedirectURI string
 
+	// AllowedRedirectURIs 可选的额外回调地址白名单（用于单次请求覆盖 redirect_uri）
+	AllowedRedirectURIs []string
+
 	// HTTPClient 可选的 HTTP 客户端
 	HTTPClient httpx.HTTPDoer
 
@@ -29,4 +36,37 @@ type Config struct {
 
 	// RefreshTokenSecret 可选的刷新令牌签名密钥
 	RefreshTokenSecret string
+
+	// Issuer 可选的授权服务器标识（RFC 9207 iss 参数）
+	Issuer string
+
+	// ClientAuthMethod 客户端认证方式（token_endpoint_auth_method），默认 client_secret_basic
+	ClientAuthMethod string
+
+	// ClientAssertionKey private_key_jwt 使用的签名私钥（*rsa.PrivateKey 或 *ecdsa.PrivateKey）
+	ClientAssertionKey crypto.Signer
+
+	// ClientAssertionKeyID private_key_jwt 断言头部的 kid，可选
+	ClientAssertionKeyID string
+
+	// TLSClientCertificate mTLS 客户端证书（tls_client_auth / self_signed_tls_client_auth，或用于证书绑定令牌）
+	TLSClientCertificate *tls.Certificate
+
+	// TLSRootCAs 可选的授权服务器根证书池，为空时使用系统根证书
+	TLSRootCAs *x509.CertPool
+
+	// RequestObjectKey 可选的请求对象签名私钥（JAR），为空时使用 ClientSecret 以 HS256 签名
+	RequestObjectKey crypto.Signer
+
+	// RequestObjectKeyID 请求对象头部的 kid，可选
+	RequestObjectKeyID string
+
+	// DPoPEnabled 是否启用 DPoP（WithDPoP），启用时 DPoPKey 必填
+	DPoPEnabled bool
+
+	// DPoPKey 可选的 DPoP 证明签名私钥（RFC 9449）
+	DPoPKey crypto.Signer
+
+	// MTLSEndpointAliases mTLS 端点别名（RFC 8705 5），key 为端点路径，value 为别名完整地址
+	MTLSEndpointAliases map[string]string
 }
@@ -1,18 +1,44 @@
 package configx
 
 import (
+	"crypto/tls"
 	"net/http"
 	"strings"
 )
 
 // Normalize 标准化配置
 // - 去掉 BaseURL 末尾的 /
+// - 若未提供 HTTPClient 但配置了 mTLS 客户端证书或根证书池，创建携带对应 TLS 配置的 HTTP 客户端
 // - 若未提供 HTTPClient，补充默认 http.DefaultClient
+// - 若未指定客户端认证方式，默认 client_secret_basic
 func Normalize(cfg *Config) {
 	cfg.FrontendBaseURL = strings.TrimSuffix(cfg.FrontendBaseURL, "/")
 	cfg.BackendBaseURL = strings.TrimSuffix(cfg.BackendBaseURL, "/")
 
+	if cfg.ClientAuthMethod == "" {
+		cfg.ClientAuthMethod = "client_secret_basic"
+	}
+
+	if cfg.HTTPClient == nil && (cfg.TLSClientCertificate != nil || cfg.TLSRootCAs != nil) {
+		cfg.HTTPClient = newTLSHTTPClient(cfg)
+	}
+
 	if cfg.HTTPClient == nil {
 		cfg.HTTPClient = http.DefaultClient
 	}
 }
+
+// newTLSHTTPClient 基于 http.DefaultTransport 创建携带客户端证书与根证书池的 HTTP 客户端
+func newTLSHTTPClient(cfg *Config) *http.Client {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		RootCAs:    cfg.TLSRootCAs,
+	}
+	if cfg.TLSClientCertificate != nil {
+		tlsConfig.Certificates = []tls.Certificate{*cfg.TLSClientCertificate}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return &http.Client{Transport: transport}
+}
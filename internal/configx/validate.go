@@ -14,11 +14,28 @@ func Validate(cfg *Config) error {
 	if cfg.ClientID == "" {
 		return fmt.Errorf("client_id is required")
 	}
-	if cfg.ClientSecret == "" {
+	// private_key_jwt、mTLS 认证方式与公开客户端不使用 client_secret
+	if cfg.ClientSecret == "" && !secretlessAuthMethods[cfg.ClientAuthMethod] {
 		return fmt.Errorf("client_secret is required")
 	}
+	// mTLS 认证的证书可以通过 TLSClientCertificate 配置，也可以由自定义的 HTTPClient 携带
+	if (cfg.ClientAuthMethod == "tls_client_auth" || cfg.ClientAuthMethod == "self_signed_tls_client_auth") &&
+		cfg.TLSClientCertificate == nil && cfg.HTTPClient == nil {
+		return fmt.Errorf("client certificate is required for %s", cfg.ClientAuthMethod)
+	}
+	if cfg.DPoPEnabled && cfg.DPoPKey == nil {
+		return fmt.Errorf("dpop key is required")
+	}
 	if cfg.RedirectURI == "" {
 		return fmt.Errorf("redirect_uri is required")
 	}
 	return nil
 }
+
+// secretlessAuthMethods 是无需 client_secret 的客户端认证方式
+var secretlessAuthMethods = map[string]bool{
+	"private_key_jwt":             true,
+	"tls_client_auth":             true,
+	"self_signed_tls_client_auth": true,
+	"none":                        true,
+}
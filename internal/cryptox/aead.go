@@ -0,0 +1,68 @@
+package cryptox
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+)
+
+// ErrDecrypt 表示密文无法被任一密钥解密（密钥不匹配或数据被篡改）
+var ErrDecrypt = errors.New("decrypt: message authentication failed")
+
+// KeyRing 持有一组 AES-GCM 密钥，用于对称加解密
+// 第一个密钥用于加密，全部密钥按顺序尝试解密，以支持密钥轮换
+type KeyRing struct {
+	aeads []cipher.AEAD
+}
+
+// NewKeyRing 创建 KeyRing
+// 每个密钥长度必须为 16、24 或 32 字节（分别对应 AES-128/192/256）
+func NewKeyRing(keys ...[]byte) (*KeyRing, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("at least one key is required")
+	}
+
+	aeads := make([]cipher.AEAD, 0, len(keys))
+	for i, key := range keys {
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, fmt.Errorf("create cipher for key %d: %w", i, err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("create gcm for key %d: %w", i, err)
+		}
+		aeads = append(aeads, aead)
+	}
+	return &KeyRing{aeads: aeads}, nil
+}
+
+// Seal 使用当前（第一个）密钥加密 plaintext
+// additionalData 参与认证但不加密，解密时必须传入相同的值
+// 返回值格式：nonce || ciphertext
+func (k *KeyRing) Seal(plaintext, additionalData []byte) ([]byte, error) {
+	aead := k.aeads[0]
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("generate nonce: %w", err)
+	}
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+// Open 依次尝试所有密钥解密 Seal 生成的密文
+// 所有密钥均失败时返回 ErrDecrypt
+func (k *KeyRing) Open(ciphertext, additionalData []byte) ([]byte, error) {
+	for _, aead := range k.aeads {
+		nonceSize := aead.NonceSize()
+		if len(ciphertext) < nonceSize+aead.Overhead() {
+			continue
+		}
+		plaintext, err := aead.Open(nil, ciphertext[:nonceSize], ciphertext[nonceSize:], additionalData)
+		if err == nil {
+			return plaintext, nil
+		}
+	}
+	return nil, ErrDecrypt
+}
@@ -0,0 +1,17 @@
+package cryptox
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+)
+
+// RandomString 生成 n 字节安全随机数，并以无填充 base64url 编码返回
+// 常用于会话 ID、state、jti 等不可预测标识
+func RandomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate random bytes: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
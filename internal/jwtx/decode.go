@@ -0,0 +1,26 @@
+package jwtx
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// DecodePayload 解码 JWT 的 payload 到 v，不校验签名
+// 仅用于读取已通过验签的令牌中的额外声明，或读取尚未验签令牌中用于选择密钥的字段
+func DecodePayload(token string, v any) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return fmt.Errorf("malformed jwt")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return fmt.Errorf("decode jwt payload: %w", err)
+	}
+	if err := json.Unmarshal(payload, v); err != nil {
+		return fmt.Errorf("parse jwt payload: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package jwtx
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+)
+
+// PublicJWK 将公钥编码为 JWK（RFC 7517），仅包含公钥参数
+// 支持 *rsa.PublicKey 与 *ecdsa.PublicKey（P-256 / P-384 / P-521）
+func PublicJWK(pub crypto.PublicKey) (map[string]any, error) {
+	switch k := pub.(type) {
+	case *ecdsa.PublicKey:
+		crv, size, err := curveParams(k.Curve)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{
+			"kty": "EC",
+			"crv": crv,
+			"x":   encodeFixed(k.X, size),
+			"y":   encodeFixed(k.Y, size),
+		}, nil
+	case *rsa.PublicKey:
+		return map[string]any{
+			"kty": "RSA",
+			"n":   base64.RawURLEncoding.EncodeToString(k.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.E)).Bytes()),
+		}, nil
+	}
+	return nil, fmt.Errorf("unsupported public key type: %T", pub)
+}
+
+// ParseJWK 从 JWK 解析公钥；包含私钥参数（d）的 JWK 会被拒绝
+func ParseJWK(jwk map[string]any) (crypto.PublicKey, error) {
+	if _, ok := jwk["d"]; ok {
+		return nil, fmt.Errorf("jwk must not contain private key")
+	}
+
+	kty, _ := jwk["kty"].(string)
+	switch kty {
+	case "EC":
+		crv, _ := jwk["crv"].(string)
+		var curve elliptic.Curve
+		switch crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported jwk curve: %s", crv)
+		}
+		x, err := decodeBigInt(jwk, "x")
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(jwk, "y")
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, fmt.Errorf("jwk point is not on curve")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "RSA":
+		n, err := decodeBigInt(jwk, "n")
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(jwk, "e")
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("invalid jwk rsa exponent")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	}
+	return nil, fmt.Errorf("unsupported jwk kty: %s", kty)
+}
+
+// Thumbprint 计算公钥的 JWK SHA-256 指纹（RFC 7638），base64url 无填充
+// 按规范只使用必需成员并按字典序拼接，结果与成员顺序无关
+func Thumbprint(pub crypto.PublicKey) (string, error) {
+	jwk, err := PublicJWK(pub)
+	if err != nil {
+		return "", err
+	}
+
+	var canonical string
+	switch jwk["kty"] {
+	case "EC":
+		canonical = fmt.Sprintf(`{"crv":%q,"kty":"EC","x":%q,"y":%q}`, jwk["crv"], jwk["x"], jwk["y"])
+	case "RSA":
+		canonical = fmt.Sprintf(`{"e":%q,"kty":"RSA","n":%q}`, jwk["e"], jwk["n"])
+	}
+
+	sum := sha256.Sum256([]byte(canonical))
+	return base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
+
+// curveParams 返回曲线的 JWK crv 名称与坐标字节长度
+func curveParams(curve elliptic.Curve) (string, int, error) {
+	switch curve {
+	case elliptic.P256():
+		return "P-256", 32, nil
+	case elliptic.P384():
+		return "P-384", 48, nil
+	case elliptic.P521():
+		return "P-521", 66, nil
+	}
+	return "", 0, fmt.Errorf("unsupported ecdsa curve: %s", curve.Params().Name)
+}
+
+// encodeFixed 将坐标按固定长度（左侧补零）编码为 base64url（RFC 7518 6.2.1.2）
+func encodeFixed(v *big.Int, size int) string {
+	buf := make([]byte, size)
+	v.FillBytes(buf)
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
+
+// decodeBigInt 解码 JWK 中 base64url 编码的整数成员
+func decodeBigInt(jwk map[string]any, name string) (*big.Int, error) {
+	s, _ := jwk[name].(string)
+	if s == "" {
+		return nil, fmt.Errorf("jwk %s is required", name)
+	}
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("decode jwk %s: %w", name, err)
+	}
+	return new(big.Int).SetBytes(b), nil
+}
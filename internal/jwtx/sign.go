@@ -0,0 +1,57 @@
+package jwtx
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// SigningMethod 根据密钥类型选择 JWS 签名算法
+//   - []byte: HS256（对称密钥，例如 client_secret）
+//   - *rsa.PrivateKey: RS256
+//   - *ecdsa.PrivateKey: 按曲线选择 ES256 / ES384 / ES512
+func SigningMethod(key any) (jwt.SigningMethod, error) {
+	switch k := key.(type) {
+	case []byte:
+		return jwt.SigningMethodHS256, nil
+	case *rsa.PrivateKey:
+		return jwt.SigningMethodRS256, nil
+	case *ecdsa.PrivateKey:
+		switch k.Curve {
+		case elliptic.P256():
+			return jwt.SigningMethodES256, nil
+		case elliptic.P384():
+			return jwt.SigningMethodES384, nil
+		case elliptic.P521():
+			return jwt.SigningMethodES512, nil
+		}
+		return nil, fmt.Errorf("unsupported ecdsa curve: %s", k.Curve.Params().Name)
+	}
+	return nil, fmt.Errorf("unsupported signing key type: %T", key)
+}
+
+// Sign 使用 key 签发 JWT
+// headers 为额外的 JOSE 头部（例如 kid、typ、jwk），alg 由密钥类型决定，不可覆盖
+func Sign(claims jwt.Claims, key any, headers map[string]any) (string, error) {
+	method, err := SigningMethod(key)
+	if err != nil {
+		return "", err
+	}
+
+	token := jwt.NewWithClaims(method, claims)
+	for name, value := range headers {
+		if name == "alg" {
+			continue
+		}
+		token.Header[name] = value
+	}
+
+	signed, err := token.SignedString(key)
+	if err != nil {
+		return "", fmt.Errorf("sign jwt: %w", err)
+	}
+	return signed, nil
+}
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
-	"strings"
 
 	"github.com/3086953492/goauthsdk/internal/httpx"
 )
@@ -83,7 +82,7 @@ func (c *Client) IntrospectTokenWithHint(ctx context.Context, token, tokenTypeHi
 // buildIntrospectRequest 构建内省请求的 HTTP 请求
 func buildIntrospectRequest(ctx context.Context, c *Client, token, tokenTypeHint string) (*http.Request, error) {
 	// 构建请求 URL
-	introspectURL := c.cfg.BackendBaseURL + "/api/v1/oauth/introspect"
+	introspectURL := c.endpointURL(introspectionEndpointPath)
 
 	// 构建表单参数
 	formData := url.Values{}
@@ -92,18 +91,12 @@ func buildIntrospectRequest(ctx context.Context, c *Client, token, tokenTypeHint
 		formData.Set("token_type_hint", tokenTypeHint)
 	}
 
-	// 创建 HTTP 请求
-	req, err := http.NewRequestWithContext(ctx, "POST", introspectURL, strings.NewReader(formData.Encode()))
+	// 创建 HTTP 请求（附加客户端认证信息）
+	req, err := newClientAuthRequest(ctx, c, introspectURL, formData)
 	if err != nil {
 		return nil, fmt.Errorf("create introspect request: %w", err)
 	}
 
-	// 设置 Content-Type
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-
-	// 设置 Basic Auth（client_id 和 client_secret）
-	req.SetBasicAuth(c.cfg.ClientID, c.cfg.ClientSecret)
-
 	return req, nil
 }
 
@@ -0,0 +1,68 @@
+package goauthsdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// jwtBearerGrantType 是 JWT Bearer 授权模式的 grant_type（RFC 7523 2.1）
+const jwtBearerGrantType = "urn:ietf:params:oauth:grant-type:jwt-bearer"
+
+// JWTBearerToken 使用 JWT Bearer 断言获取访问令牌（RFC 7523 2.1）
+// 适用于已由受信任签发方（例如企业 IdP 或上游服务）签发用户断言的场景，无需用户再次交互授权
+//
+// 参数:
+//   - ctx: 上下文，用于控制请求超时等
+//   - assertion: 已签名的 JWT 断言（sub 为用户标识，aud 为授权服务器）
+//   - scope: 可选的权限范围，多个 scope 用空格分隔
+//
+// 示例用法:
+//
+//	token, err := client.JWTBearerToken(context.Background(), signedAssertion, "profile")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("Access Token: %s\n", token.AccessToken.AccessToken)
+func (c *Client) JWTBearerToken(ctx context.Context, assertion, scope string) (*TokenResponse, error) {
+	if assertion == "" {
+		return nil, fmt.Errorf("assertion is required")
+	}
+
+	// 构建并发送请求
+	req, err := buildJWTBearerTokenRequest(ctx, c, assertion, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, body, err := doTokenRequest(c, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// 解析响应
+	return parseTokenResponse(resp, body)
+}
+
+// buildJWTBearerTokenRequest 构建 JWT Bearer 授权模式的 HTTP 请求
+func buildJWTBearerTokenRequest(ctx context.Context, c *Client, assertion, scope string) (*http.Request, error) {
+	// 构建请求 URL
+	tokenURL := c.endpointURL(tokenEndpointPath)
+
+	// 构建表单参数
+	formData := url.Values{}
+	formData.Set("grant_type", jwtBearerGrantType)
+	formData.Set("assertion", assertion)
+	if scope != "" {
+		formData.Set("scope", scope)
+	}
+
+	// 创建 HTTP 请求（附加客户端认证信息）
+	req, err := newClientAuthRequest(ctx, c, tokenURL, formData)
+	if err != nil {
+		return nil, fmt.Errorf("create jwt bearer token request: %w", err)
+	}
+
+	return req, nil
+}
@@ -4,12 +4,16 @@ import (
 	"fmt"
 
 	"github.com/3086953492/gokit/jwt"
+	gojwt "github.com/golang-jwt/jwt/v5"
 )
 
 // JWTVerifier 提供 JWT 离线验签能力
 // 可独立使用，也可由 Client 持有
 type JWTVerifier struct {
 	manager *jwt.Manager
+
+	// accessSecret 用于校验访问令牌之外、由授权服务器以相同密钥签发的 JWT（例如登出令牌）
+	accessSecret []byte
 }
 
 // NewJWTVerifier 创建一个新的 JWTVerifier
@@ -33,7 +37,7 @@ func NewJWTVerifier(accessTokenSecret, refreshTokenSecret string) (*JWTVerifier,
 	if err != nil {
 		return nil, fmt.Errorf("create jwt manager: %w", err)
 	}
-	return &JWTVerifier{manager: mgr}, nil
+	return &JWTVerifier{manager: mgr, accessSecret: []byte(accessTokenSecret)}, nil
 }
 
 // ParseAccessToken 离线解析并验证访问令牌
@@ -82,3 +86,15 @@ func (v *JWTVerifier) ValidateToken(token string) error {
 	}
 	return v.manager.ValidateToken(token)
 }
+
+// parseSignedJWT 使用访问令牌签名密钥（HS256）校验 JWT 签名并解码 claims
+// 用于校验登出令牌等非访问令牌的 JWT，claims 的业务校验由调用方完成
+func (v *JWTVerifier) parseSignedJWT(token string, claims gojwt.Claims, opts ...gojwt.ParserOption) (*gojwt.Token, error) {
+	if len(v.accessSecret) == 0 {
+		return nil, ErrJWTNotConfigured
+	}
+	opts = append(opts, gojwt.WithValidMethods([]string{gojwt.SigningMethodHS256.Alg()}))
+	return gojwt.ParseWithClaims(token, claims, func(*gojwt.Token) (any, error) {
+		return v.accessSecret, nil
+	}, opts...)
+}
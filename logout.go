@@ -0,0 +1,119 @@
+package goauthsdk
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// LogoutError 表示 Logout 撤销令牌时部分或全部失败
+// 可通过 errors.As 获取每个令牌的撤销结果；errors.Is / errors.As 也会匹配其中的底层错误
+type LogoutError struct {
+	// RefreshTokenErr 撤销刷新令牌的错误，成功或未提供刷新令牌时为 nil
+	RefreshTokenErr error
+
+	// AccessTokenErr 撤销访问令牌的错误，成功或未提供访问令牌时为 nil
+	AccessTokenErr error
+}
+
+// Error 实现 error 接口
+func (e *LogoutError) Error() string {
+	var parts []string
+	if e.RefreshTokenErr != nil {
+		parts = append(parts, fmt.Sprintf("revoke refresh_token: %v", e.RefreshTokenErr))
+	}
+	if e.AccessTokenErr != nil {
+		parts = append(parts, fmt.Sprintf("revoke access_token: %v", e.AccessTokenErr))
+	}
+	return "logout: " + strings.Join(parts, "; ")
+}
+
+// Unwrap 返回底层错误，供 errors.Is / errors.As 匹配
+func (e *LogoutError) Unwrap() []error {
+	var errs []error
+	if e.RefreshTokenErr != nil {
+		errs = append(errs, e.RefreshTokenErr)
+	}
+	if e.AccessTokenErr != nil {
+		errs = append(errs, e.AccessTokenErr)
+	}
+	return errs
+}
+
+// BuildLogoutURL 构建 RP 发起登出时跳转的前端结束会话地址（OIDC RP-Initiated Logout）
+// 用户浏览器重定向到该地址后，授权服务器结束用户的登录会话，再跳转回 postLogoutRedirectURI
+//
+// 参数:
+//   - idTokenHint: 可选的 ID Token，提示授权服务器要登出的用户会话
+//   - postLogoutRedirectURI: 可选的登出后回跳地址，需在客户端注册的登出回调白名单中
+//   - state: 可选的状态参数，会原样附加在回跳地址上
+//
+// 示例用法:
+//
+//	// 先撤销令牌并清理本地会话
+//	if err := client.Logout(ctx, token); err != nil {
+//	    log.Println(err)
+//	}
+//	logoutURL, err := client.BuildLogoutURL(idToken, "https://yourapp.com/logged-out", state)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	http.Redirect(w, r, logoutURL, http.StatusFound)
+func (c *Client) BuildLogoutURL(idTokenHint, postLogoutRedirectURI, state string) (string, error) {
+	// 构造前端结束会话地址
+	u, err := url.Parse(c.cfg.FrontendBaseURL + "/oauth/logout")
+	if err != nil {
+		return "", fmt.Errorf("parse frontend base url: %w", err)
+	}
+
+	// 构建 query 参数
+	q := url.Values{}
+	q.Set("client_id", c.cfg.ClientID)
+	if idTokenHint != "" {
+		q.Set("id_token_hint", idTokenHint)
+	}
+	if postLogoutRedirectURI != "" {
+		q.Set("post_logout_redirect_uri", postLogoutRedirectURI)
+	}
+	if state != "" {
+		q.Set("state", state)
+	}
+
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// Logout 撤销用户的刷新令牌与访问令牌
+// 按 RFC 7009 建议先撤销刷新令牌（服务端通常会同时使由其签发的访问令牌失效），再撤销访问令牌；
+// 其中一个失败不影响另一个的撤销，失败时返回 *LogoutError 报告各自结果
+//
+// 参数:
+//   - ctx: 上下文，用于控制请求超时等
+//   - tokens: 需要撤销的令牌，为空的令牌会被跳过
+//
+// 示例用法:
+//
+//	err := client.Logout(ctx, token)
+//	var logoutErr *goauthsdk.LogoutError
+//	if errors.As(err, &logoutErr) && logoutErr.RefreshTokenErr != nil {
+//	    // 刷新令牌撤销失败，需要告警或重试
+//	}
+func (c *Client) Logout(ctx context.Context, tokens *TokenResponse) error {
+	if tokens == nil {
+		return fmt.Errorf("tokens is required")
+	}
+
+	var logoutErr LogoutError
+	if tokens.RefreshToken.RefreshToken != "" {
+		logoutErr.RefreshTokenErr = c.RevokeTokenWithHint(ctx, tokens.RefreshToken.RefreshToken, "refresh_token")
+	}
+	if tokens.AccessToken.AccessToken != "" {
+		logoutErr.AccessTokenErr = c.RevokeTokenWithHint(ctx, tokens.AccessToken.AccessToken, "access_token")
+	}
+
+	if logoutErr.RefreshTokenErr != nil || logoutErr.AccessTokenErr != nil {
+		return &logoutErr
+	}
+	return nil
+}
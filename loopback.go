@@ -0,0 +1,202 @@
+package goauthsdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/3086953492/goauthsdk/internal/cryptox"
+)
+
+// DefaultLoopbackTimeout 是 LoopbackLogin 等待浏览器回调的默认时长
+const DefaultLoopbackTimeout = 5 * time.Minute
+
+// LoopbackOption 用于配置 LoopbackLogin 的可选参数
+type LoopbackOption func(*loopbackParams)
+
+// loopbackParams 是 LoopbackLogin 的参数集合
+type loopbackParams struct {
+	path        string
+	timeout     time.Duration
+	openBrowser func(authURL string) error
+	authOpts    []AuthorizationOption
+}
+
+// WithLoopbackPath 设置回调路径，需与注册的 loopback redirect_uri 路径一致，默认 /callback
+func WithLoopbackPath(path string) LoopbackOption {
+	return func(p *loopbackParams) {
+		p.path = path
+	}
+}
+
+// WithLoopbackTimeout 设置等待浏览器回调的最长时间，默认 DefaultLoopbackTimeout
+func WithLoopbackTimeout(d time.Duration) LoopbackOption {
+	return func(p *loopbackParams) {
+		p.timeout = d
+	}
+}
+
+// WithBrowserOpener 设置打开授权地址的方式，例如调用系统浏览器
+// 默认将授权地址打印到标准错误输出，由用户手动打开；测试中可传入使用 HTTP 客户端模拟浏览器的函数
+func WithBrowserOpener(open func(authURL string) error) LoopbackOption {
+	return func(p *loopbackParams) {
+		p.openBrowser = open
+	}
+}
+
+// WithLoopbackAuthorizationOptions 追加授权请求参数，例如 WithPrompt、WithResource
+func WithLoopbackAuthorizationOptions(opts ...AuthorizationOption) LoopbackOption {
+	return func(p *loopbackParams) {
+		p.authOpts = append(p.authOpts, opts...)
+	}
+}
+
+// printAuthorizationURL 是默认的浏览器打开方式：将授权地址打印到 w
+func printAuthorizationURL(w io.Writer) func(string) error {
+	return func(authURL string) error {
+		_, err := fmt.Fprintf(w, "在浏览器中打开以下地址完成登录：\n\n  %s\n\n", authURL)
+		return err
+	}
+}
+
+// LoopbackLogin 为原生应用与命令行工具完成授权码登录（RFC 8252 loopback redirect + PKCE）
+// 在 127.0.0.1 的临时端口上启动一次性回调服务，使用 http://127.0.0.1:<port><path> 作为 redirect_uri 构建带 PKCE 的授权地址，
+// 打开授权地址后等待回调（校验 state），用授权码交换令牌，最后关闭回调服务
+//
+// 客户端的 RedirectURI 或 WithAllowedRedirectURIs 中需包含 http://127.0.0.1<path>（端口任意），
+// 授权服务器同样需要允许 loopback 地址使用任意端口；公开客户端可配合 WithClientAuthMethod(ClientAuthNone) 使用
+//
+// 参数:
+//   - ctx: 上下文，取消时停止等待
+//   - scope: 申请的权限范围
+//   - opts: 可选配置
+//
+// 示例用法:
+//
+//	client, err := goauthsdk.NewClient(frontend, backend, "cli-client-id", "", "http://127.0.0.1/callback",
+//	    goauthsdk.WithClientAuthMethod(goauthsdk.ClientAuthNone),
+//	)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	token, err := client.LoopbackLogin(ctx, "openid profile",
+//	    goauthsdk.WithBrowserOpener(func(authURL string) error {
+//	        return exec.Command("xdg-open", authURL).Start()
+//	    }),
+//	)
+func (c *Client) LoopbackLogin(ctx context.Context, scope string, opts ...LoopbackOption) (*TokenResponse, error) {
+	params := &loopbackParams{
+		path:        "/callback",
+		timeout:     DefaultLoopbackTimeout,
+		openBrowser: printAuthorizationURL(os.Stderr),
+	}
+	for _, opt := range opts {
+		opt(params)
+	}
+	if params.timeout <= 0 {
+		params.timeout = DefaultLoopbackTimeout
+	}
+
+	state, err := cryptox.RandomString(16)
+	if err != nil {
+		return nil, err
+	}
+	pkce, err := GeneratePKCE()
+	if err != nil {
+		return nil, err
+	}
+
+	// 绑定 127.0.0.1 而非 localhost，避免解析到非 loopback 接口（RFC 8252 8.3）
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("start loopback listener: %w", err)
+	}
+	redirectURI := fmt.Sprintf("http://%s%s", listener.Addr().String(), params.path)
+
+	authURL, err := c.BuildAuthorizationURL(state, scope,
+		append(params.authOpts, WithRedirectURI(redirectURI), WithPKCE(pkce))...)
+	if err != nil {
+		listener.Close()
+		return nil, err
+	}
+
+	results := make(chan loopbackResult, 1)
+	server := &http.Server{
+		Handler:           c.loopbackCallbackHandler(params.path, state, results),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() { _ = server.Serve(listener) }()
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	if err := params.openBrowser(authURL); err != nil {
+		return nil, fmt.Errorf("open authorization url: %w", err)
+	}
+
+	timer := time.NewTimer(params.timeout)
+	defer timer.Stop()
+
+	var result loopbackResult
+	select {
+	case result = <-results:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-timer.C:
+		return nil, fmt.Errorf("timed out waiting for authorization callback")
+	}
+	if result.err != nil {
+		return nil, result.err
+	}
+
+	return c.ExchangeToken(ctx, result.code, WithTokenRedirectURI(redirectURI), WithCodeVerifier(pkce.Verifier))
+}
+
+// loopbackResult 是回调处理的结果
+type loopbackResult struct {
+	code string
+	err  error
+}
+
+// loopbackCallbackHandler 处理 loopback 回调：state 不一致的请求返回 400 并继续等待，其余结果只投递一次
+func (c *Client) loopbackCallbackHandler(path, state string, results chan<- loopbackResult) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+
+		resp, err := c.ParseAuthorizationCallback(r, state)
+		if errors.Is(err, ErrStateMismatch) {
+			http.Error(w, "state 校验失败，请重新登录", http.StatusBadRequest)
+			return
+		}
+
+		select {
+		case results <- loopbackResult{code: codeOf(resp), err: err}:
+		default:
+			http.Error(w, "登录已处理，可以关闭此页面", http.StatusGone)
+			return
+		}
+		if err != nil {
+			http.Error(w, "登录失败，请返回终端查看错误信息", http.StatusBadRequest)
+			return
+		}
+		_, _ = io.WriteString(w, "登录成功，可以关闭此页面并返回终端。\n")
+	})
+	return mux
+}
+
+// codeOf 返回授权响应中的授权码，resp 为 nil 时返回空字符串
+func codeOf(resp *AuthorizationResponse) string {
+	if resp == nil {
+		return ""
+	}
+	return resp.Code
+}
@@ -0,0 +1,163 @@
+package goauthsdk_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/3086953492/goauthsdk"
+	"github.com/3086953492/goauthsdk/goauthtest"
+)
+
+// newLoopbackClient 启动模拟授权服务器并创建指向它的 Client
+func newLoopbackClient(t *testing.T, opts ...goauthtest.Option) (*goauthtest.Server, *goauthsdk.Client) {
+	t.Helper()
+	srv := goauthtest.NewServer(opts...)
+	t.Cleanup(srv.Close)
+	client, err := srv.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return srv, client
+}
+
+// browser 模拟浏览器：在模拟授权服务器完成授权后访问 loopback 回调地址
+func browser(t *testing.T, srv *goauthtest.Server) func(authURL string) error {
+	return func(authURL string) error {
+		go func() {
+			values, err := srv.Authorize(context.Background(), authURL)
+			if err != nil {
+				t.Errorf("authorize: %v", err)
+				return
+			}
+			u, _ := url.Parse(authURL)
+			if _, err := callback(u.Query().Get("redirect_uri"), values); err != nil {
+				t.Errorf("callback: %v", err)
+			}
+		}()
+		return nil
+	}
+}
+
+// callback 访问 loopback 回调地址并返回状态码
+func callback(redirectURI string, values url.Values) (int, error) {
+	resp, err := http.Get(redirectURI + "?" + values.Encode())
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+func TestLoopbackLogin(t *testing.T) {
+	srv, client := newLoopbackClient(t)
+
+	token, err := client.LoopbackLogin(context.Background(), "openid profile",
+		goauthsdk.WithBrowserOpener(browser(t, srv)),
+		goauthsdk.WithLoopbackTimeout(5*time.Second),
+	)
+	if err != nil {
+		t.Fatalf("LoopbackLogin: %v", err)
+	}
+	if token.AccessToken.AccessToken == "" || token.RefreshToken.RefreshToken == "" {
+		t.Fatalf("token = %+v, want access and refresh token", token)
+	}
+	claims, err := client.JWTVerifier().ParseAccessToken(token.AccessToken.AccessToken)
+	if err != nil {
+		t.Fatalf("ParseAccessToken: %v", err)
+	}
+	if claims.Subject != goauthtest.DefaultUser.Subject {
+		t.Errorf("subject = %q, want %q", claims.Subject, goauthtest.DefaultUser.Subject)
+	}
+}
+
+func TestLoopbackLoginStateMismatchKeepsWaiting(t *testing.T) {
+	srv, client := newLoopbackClient(t)
+
+	opener := func(authURL string) error {
+		go func() {
+			values, err := srv.Authorize(context.Background(), authURL)
+			if err != nil {
+				t.Errorf("authorize: %v", err)
+				return
+			}
+			u, _ := url.Parse(authURL)
+			redirectURI := u.Query().Get("redirect_uri")
+
+			// 伪造 state 的回调被拒绝，处理器继续等待真正的回调
+			forged := url.Values{"code": {"forged-code"}, "state": {"forged-state"}, "iss": values["iss"]}
+			if status, err := callback(redirectURI, forged); err != nil || status != http.StatusBadRequest {
+				t.Errorf("forged callback: status %d, err %v, want 400", status, err)
+			}
+			if status, err := callback(redirectURI, values); err != nil || status != http.StatusOK {
+				t.Errorf("callback: status %d, err %v, want 200", status, err)
+			}
+		}()
+		return nil
+	}
+
+	token, err := client.LoopbackLogin(context.Background(), "profile",
+		goauthsdk.WithBrowserOpener(opener),
+		goauthsdk.WithLoopbackTimeout(5*time.Second),
+	)
+	if err != nil {
+		t.Fatalf("LoopbackLogin: %v", err)
+	}
+	if token.AccessToken.AccessToken == "" {
+		t.Fatal("access token is empty")
+	}
+}
+
+func TestLoopbackLoginAccessDenied(t *testing.T) {
+	srv, client := newLoopbackClient(t)
+	srv.DenyConsent(true)
+
+	_, err := client.LoopbackLogin(context.Background(), "profile",
+		goauthsdk.WithBrowserOpener(browser(t, srv)),
+		goauthsdk.WithLoopbackTimeout(5*time.Second),
+	)
+	if !errors.Is(err, goauthsdk.ErrAccessDenied) {
+		t.Fatalf("got %v, want ErrAccessDenied", err)
+	}
+}
+
+func TestLoopbackLoginTimeout(t *testing.T) {
+	_, client := newLoopbackClient(t)
+
+	var redirectURI string
+	_, err := client.LoopbackLogin(context.Background(), "profile",
+		goauthsdk.WithBrowserOpener(func(authURL string) error {
+			u, err := url.Parse(authURL)
+			if err != nil {
+				return err
+			}
+			redirectURI = u.Query().Get("redirect_uri")
+			return nil
+		}),
+		goauthsdk.WithLoopbackTimeout(50*time.Millisecond),
+	)
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("got %v, want timeout error", err)
+	}
+
+	// 超时后回调服务已关闭
+	if _, err := callback(redirectURI, url.Values{}); err == nil {
+		t.Error("loopback listener still accepts connections after timeout")
+	}
+}
+
+func TestLoopbackLoginOpenerError(t *testing.T) {
+	_, client := newLoopbackClient(t)
+
+	_, err := client.LoopbackLogin(context.Background(), "profile",
+		goauthsdk.WithBrowserOpener(func(string) error { return fmt.Errorf("no browser") }),
+	)
+	if err == nil || !strings.Contains(err.Error(), "no browser") {
+		t.Fatalf("got %v, want opener error", err)
+	}
+}
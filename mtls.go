@@ -0,0 +1,120 @@
+package goauthsdk
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/3086953492/goauthsdk/internal/jwtx"
+	"github.com/3086953492/gokit/jwt"
+)
+
+// ErrCertificateBindingMismatch 表示访问令牌绑定的证书与请求方出示的证书不一致，或令牌未绑定证书
+var ErrCertificateBindingMismatch = errors.New("access token is not bound to the presented certificate")
+
+// MTLSEndpointAliases mTLS 端点别名（RFC 8705 5），字段与授权服务器元数据 mtls_endpoint_aliases 对齐
+// 值为完整地址，为空的字段表示该端点没有别名
+type MTLSEndpointAliases struct {
+	TokenEndpoint               string `json:"token_endpoint,omitempty"`
+	IntrospectionEndpoint       string `json:"introspection_endpoint,omitempty"`
+	RevocationEndpoint          string `json:"revocation_endpoint,omitempty"`
+	UserInfoEndpoint            string `json:"userinfo_endpoint,omitempty"`
+	DeviceAuthorizationEndpoint string `json:"device_authorization_endpoint,omitempty"`
+	PushedAuthorizationEndpoint string `json:"pushed_authorization_request_endpoint,omitempty"`
+}
+
+// byPath 将别名转换为以端点路径为 key 的映射，供 endpointURL 查找
+func (a MTLSEndpointAliases) byPath() map[string]string {
+	aliases := map[string]string{
+		tokenEndpointPath:               a.TokenEndpoint,
+		introspectionEndpointPath:       a.IntrospectionEndpoint,
+		revocationEndpointPath:          a.RevocationEndpoint,
+		userInfoEndpointPath:            a.UserInfoEndpoint,
+		deviceAuthorizationEndpointPath: a.DeviceAuthorizationEndpoint,
+		pushedAuthorizationEndpointPath: a.PushedAuthorizationEndpoint,
+	}
+	for path, alias := range aliases {
+		if alias == "" {
+			delete(aliases, path)
+		}
+	}
+	return aliases
+}
+
+// CertificateThumbprint 计算证书的 x5t#S256 指纹（DER 编码的 SHA-256 摘要，base64url 无填充）
+func CertificateThumbprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// VerifyCertificateBinding 校验 cnf 声明绑定的证书与 cert 一致
+// 适用于通过 Introspect 获得的 IntrospectionResponse.Cnf；令牌未绑定证书时返回 ErrCertificateBindingMismatch
+func VerifyCertificateBinding(cnf *Confirmation, cert *x509.Certificate) error {
+	if cert == nil {
+		return fmt.Errorf("client certificate is required")
+	}
+	if cnf == nil || cnf.X5tS256 == "" {
+		return ErrCertificateBindingMismatch
+	}
+	if subtle.ConstantTimeCompare([]byte(cnf.X5tS256), []byte(CertificateThumbprint(cert))) != 1 {
+		return ErrCertificateBindingMismatch
+	}
+	return nil
+}
+
+// ParseCertificateBoundAccessToken 离线解析访问令牌，并校验其 cnf.x5t#S256 与请求方出示的证书一致（RFC 8705 3）
+//
+// 参数:
+//   - token: 需要解析的访问令牌字符串
+//   - cert: 资源请求 TLS 握手中的客户端证书，通常为 r.TLS.PeerCertificates[0]
+func (v *JWTVerifier) ParseCertificateBoundAccessToken(token string, cert *x509.Certificate) (*jwt.Claims, error) {
+	claims, err := v.ParseAccessToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	// 签名已校验，可以安全读取 cnf
+	var payload struct {
+		Cnf *Confirmation `json:"cnf"`
+	}
+	if err := jwtx.DecodePayload(token, &payload); err != nil {
+		return nil, err
+	}
+	if err := VerifyCertificateBinding(payload.Cnf, cert); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// ParseCertificateBoundAccessToken 离线解析证书绑定的访问令牌（RFC 8705 3）
+// 在 ParseAccessToken 的基础上校验令牌的 cnf.x5t#S256 与请求方出示的客户端证书一致，
+// 防止令牌泄露后被未持有对应私钥的一方使用
+//
+// 参数:
+//   - token: 需要解析的访问令牌字符串
+//   - cert: 资源请求 TLS 握手中的客户端证书
+//
+// 返回值:
+//   - *jwt.Claims: 解析出的令牌声明
+//   - error: 令牌无效时返回解析错误；未绑定或证书不一致时返回的错误满足 errors.Is(err, ErrCertificateBindingMismatch)
+//
+// 示例用法:
+//
+//	if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+//	    http.Error(w, "client certificate required", http.StatusUnauthorized)
+//	    return
+//	}
+//	claims, err := client.ParseCertificateBoundAccessToken(accessToken, r.TLS.PeerCertificates[0])
+//	if err != nil {
+//	    http.Error(w, "invalid token", http.StatusUnauthorized)
+//	    return
+//	}
+func (c *Client) ParseCertificateBoundAccessToken(token string, cert *x509.Certificate) (*jwt.Claims, error) {
+	if c.jwtVerifier == nil {
+		return nil, ErrJWTNotConfigured
+	}
+	return c.jwtVerifier.ParseCertificateBoundAccessToken(token, cert)
+}
@@ -0,0 +1,165 @@
+package goauthsdk
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newTestCA 生成自签名 CA 证书与私钥
+func newTestCA(t *testing.T) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, key
+}
+
+// newTestClientCertificate 生成由 CA 签发的客户端证书
+func newTestClientCertificate(t *testing.T, ca *x509.Certificate, caKey *ecdsa.PrivateKey) tls.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "test-client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}
+}
+
+// newMTLSServer 启动要求客户端证书的 TLS 服务，记录收到的请求与客户端证书
+func newMTLSServer(t *testing.T, ca *x509.Certificate, requests chan<- *http.Request) *httptest.Server {
+	t.Helper()
+	pool := x509.NewCertPool()
+	pool.AddCert(ca)
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Error(err)
+		}
+		requests <- r
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"code":0,"message":"success","data":{"access_token":"token","expires_in":3600,"token_type":"Bearer","scope":""}}`))
+	}))
+	srv.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: pool}
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestTLSClientAuthUsesEndpointAlias(t *testing.T) {
+	ca, caKey := newTestCA(t)
+	cert := newTestClientCertificate(t, ca, caKey)
+
+	requests := make(chan *http.Request, 1)
+	alias := newMTLSServer(t, ca, requests)
+	roots := x509.NewCertPool()
+	roots.AddCert(alias.Certificate())
+
+	client, err := NewClient("https://portal.example.com", "https://backend.invalid", "client-1", "", "https://app.example.com/callback",
+		WithTLSClientAuth(cert),
+		WithTLSRootCAs(roots),
+		WithMTLSEndpointAliases(MTLSEndpointAliases{TokenEndpoint: alias.URL + tokenEndpointPath}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.ClientCredentialsToken(context.Background(), ""); err != nil {
+		t.Fatalf("ClientCredentialsToken: %v", err)
+	}
+	r := <-requests
+	if r.URL.Path != tokenEndpointPath {
+		t.Errorf("path = %q, want %q", r.URL.Path, tokenEndpointPath)
+	}
+	if len(r.TLS.PeerCertificates) == 0 || CertificateThumbprint(r.TLS.PeerCertificates[0]) != CertificateThumbprint(cert.Leaf) {
+		t.Errorf("server did not receive the client certificate")
+	}
+	if got := r.PostForm.Get("client_id"); got != "client-1" {
+		t.Errorf("client_id = %q, want client-1", got)
+	}
+	if _, _, ok := r.BasicAuth(); ok || r.PostForm.Has("client_secret") {
+		t.Errorf("mTLS request must not carry a client secret")
+	}
+}
+
+func TestEndpointAliasesIgnoredWithoutMTLS(t *testing.T) {
+	aliases := WithMTLSEndpointAliases(MTLSEndpointAliases{TokenEndpoint: "https://mtls.example.com/token"})
+
+	client, err := NewClient("https://portal.example.com", "https://auth.example.com", "client-1", "secret", "https://app.example.com/callback", aliases)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := client.endpointURL(tokenEndpointPath), "https://auth.example.com"+tokenEndpointPath; got != want {
+		t.Errorf("client_secret_basic endpoint = %q, want %q", got, want)
+	}
+
+	ca, caKey := newTestCA(t)
+	cert := newTestClientCertificate(t, ca, caKey)
+	bound, err := NewClient("https://portal.example.com", "https://auth.example.com", "client-1", "", "https://app.example.com/callback",
+		aliases, WithClientAuthMethod(ClientAuthNone), WithTLSClientCertificate(cert))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := bound.endpointURL(tokenEndpointPath); got != "https://mtls.example.com/token" {
+		t.Errorf("certificate-bound endpoint = %q, want alias", got)
+	}
+}
+
+func TestVerifyCertificateBinding(t *testing.T) {
+	ca, caKey := newTestCA(t)
+	cert := newTestClientCertificate(t, ca, caKey).Leaf
+	other := newTestClientCertificate(t, ca, caKey).Leaf
+
+	if err := VerifyCertificateBinding(&Confirmation{X5tS256: CertificateThumbprint(cert)}, cert); err != nil {
+		t.Errorf("matching certificate: %v", err)
+	}
+	if err := VerifyCertificateBinding(&Confirmation{X5tS256: CertificateThumbprint(cert)}, other); !errors.Is(err, ErrCertificateBindingMismatch) {
+		t.Errorf("other certificate: got %v, want ErrCertificateBindingMismatch", err)
+	}
+	if err := VerifyCertificateBinding(nil, cert); !errors.Is(err, ErrCertificateBindingMismatch) {
+		t.Errorf("unbound token: got %v, want ErrCertificateBindingMismatch", err)
+	}
+}
@@ -10,4 +10,46 @@ type IntrospectionResponse struct {
 	TokenType string `json:"token_type,omitempty"` // 令牌类型
 	Exp       int64  `json:"exp,omitempty"`        // 过期时间戳（Unix 时间戳，秒）
 	Sub       string `json:"sub,omitempty"`        // 主体标识
+
+	ACR      string   `json:"acr,omitempty"`       // 认证上下文等级，例如 mfa
+	AMR      []string `json:"amr,omitempty"`       // 认证方式，例如 pwd、otp
+	AuthTime int64    `json:"auth_time,omitempty"` // 用户认证时间（Unix 时间戳，秒）
+
+	Cnf *Confirmation `json:"cnf,omitempty"` // 令牌绑定的确认信息（RFC 8705 / RFC 9449），未绑定时为 nil
+
+	AuthorizationDetails AuthorizationDetails `json:"authorization_details,omitempty"` // 令牌包含的授权详情（RFC 9396 9.2）
+}
+
+// Confirmation 令牌的 cnf 确认声明，描述令牌绑定的持有者凭据
+type Confirmation struct {
+	X5tS256 string `json:"x5t#S256,omitempty"` // 绑定的客户端证书 SHA-256 指纹（RFC 8705 3.1）
+	JKT     string `json:"jkt,omitempty"`      // 绑定的 DPoP 公钥 JWK 指纹（RFC 9449 6）
+}
+
+// AuthorizationResponse 授权回调中解析出的授权响应
+// 由 ParseAuthorizationResponse / ParseAuthorizationCallback 返回，Code 可直接传给 ExchangeToken
+type AuthorizationResponse struct {
+	Code   string `json:"code"`            // 授权码
+	State  string `json:"state,omitempty"` // 回传的 state（已校验与发起时一致）
+	Issuer string `json:"iss,omitempty"`   // 授权服务器标识（RFC 9207），服务端未返回时为空
+
+	SessionState string `json:"session_state,omitempty"` // 授权服务器会话状态（OIDC Session Management），用于会话检查
+}
+
+// DeviceAuthorizationResponse 设备授权响应（RFC 8628 3.2）
+// 由 RequestDeviceAuthorization 返回，用于向用户展示 UserCode 与验证地址，并传给 PollDeviceToken 轮询令牌
+type DeviceAuthorizationResponse struct {
+	DeviceCode              string `json:"device_code"`                         // 设备码（仅用于轮询，不应展示给用户）
+	UserCode                string `json:"user_code"`                           // 用户码，展示给用户在验证页面输入
+	VerificationURI         string `json:"verification_uri"`                    // 验证页面地址
+	VerificationURIComplete string `json:"verification_uri_complete,omitempty"` // 包含用户码的完整验证地址（适合生成二维码）
+	ExpiresIn               int    `json:"expires_in"`                          // 设备码有效期（秒）
+	Interval                int    `json:"interval,omitempty"`                  // 最小轮询间隔（秒），未返回时默认 5 秒
+}
+
+// PushedAuthorizationResponse 推送授权请求的响应（RFC 9126 2.2）
+// 由 PushAuthorizationRequest 返回，RequestURI 传给 BuildPushedAuthorizationURL 生成授权地址
+type PushedAuthorizationResponse struct {
+	RequestURI string `json:"request_uri"` // 一次性的请求引用，例如 urn:ietf:params:oauth:request_uri:xxx
+	ExpiresIn  int    `json:"expires_in"`  // request_uri 有效期（秒）
 }
@@ -1,6 +1,10 @@
 package goauthsdk
 
 import (
+	"crypto"
+	"crypto/tls"
+	"crypto/x509"
+
 	"github.com/3086953492/goauthsdk/internal/configx"
 	"github.com/3086953492/goauthsdk/internal/httpx"
 )
@@ -37,3 +41,114 @@ func WithJWTSecrets(accessSecret, refreshSecret string) ClientOption {
 		cfg.RefreshTokenSecret = refreshSecret
 	}
 }
+
+// WithIssuer 设置授权服务器标识（issuer）
+// 配置后，ParseAuthorizationResponse 要求回调必须携带与之一致的 iss 参数（RFC 9207），防止混淆攻击（mix-up attack）；
+// 授权服务器不支持 iss 参数（authorization_response_iss_parameter_supported）时不要配置
+func WithIssuer(issuer string) ClientOption {
+	return func(cfg *configx.Config) {
+		cfg.Issuer = issuer
+	}
+}
+
+// WithAllowedRedirectURIs 设置额外允许的回调地址
+// 授权请求通过 WithRedirectURI 覆盖 redirect_uri 时，取值必须为初始化时的 redirectURI 或此处配置的地址之一
+func WithAllowedRedirectURIs(redirectURIs ...string) ClientOption {
+	return func(cfg *configx.Config) {
+		cfg.AllowedRedirectURIs = append(cfg.AllowedRedirectURIs, redirectURIs...)
+	}
+}
+
+// WithClientAuthMethod 设置客户端认证方式
+// 可选值：ClientAuthSecretBasic（默认）、ClientAuthSecretPost、ClientAuthSecretJWT、ClientAuthNone（公开客户端，clientSecret 可传空字符串）；
+// 使用私钥认证请改用 WithPrivateKeyJWT
+func WithClientAuthMethod(method string) ClientOption {
+	return func(cfg *configx.Config) {
+		cfg.ClientAuthMethod = method
+	}
+}
+
+// WithPrivateKeyJWT 使用 private_key_jwt 客户端认证（RFC 7523）
+// 每次请求使用 key 签发有效期 1 分钟、jti 唯一的客户端断言；此时 clientSecret 可传空字符串
+//
+// 参数:
+//   - key: 签名私钥，支持 *rsa.PrivateKey（RS256）与 *ecdsa.PrivateKey（ES256/ES384/ES512）
+//   - keyID: 断言头部的 kid，需与注册到授权服务器的公钥一致；为空时不设置
+func WithPrivateKeyJWT(key crypto.Signer, keyID string) ClientOption {
+	return func(cfg *configx.Config) {
+		cfg.ClientAuthMethod = "private_key_jwt"
+		cfg.ClientAssertionKey = key
+		cfg.ClientAssertionKeyID = keyID
+	}
+}
+
+// WithRequestObjectSigningKey 设置签名请求对象（JAR，RFC 9101）使用的私钥
+// 配合 WithSignedRequestObject 使用；未设置时使用 client_secret 以 HS256 签名
+//
+// 参数:
+//   - key: 签名私钥，支持 *rsa.PrivateKey（RS256）与 *ecdsa.PrivateKey（ES256/ES384/ES512）
+//   - keyID: 请求对象头部的 kid，需与注册到授权服务器的公钥一致；为空时不设置
+func WithRequestObjectSigningKey(key crypto.Signer, keyID string) ClientOption {
+	return func(cfg *configx.Config) {
+		cfg.RequestObjectKey = key
+		cfg.RequestObjectKeyID = keyID
+	}
+}
+
+// WithDPoP 启用 DPoP（RFC 9449）：令牌请求与资源请求附加由 key 签发的 DPoP 证明，签发的令牌绑定到该密钥
+// 服务端通过 DPoP-Nonce 要求携带新 nonce 时自动重试一次；绑定到该密钥的访问令牌使用 Authorization: DPoP <token>，
+// 其余访问令牌仍使用 Bearer；key 为 nil 时 NewClient 返回错误
+func WithDPoP(key *DPoPKey) ClientOption {
+	return func(cfg *configx.Config) {
+		cfg.DPoPEnabled = true
+		cfg.DPoPKey = nil
+		if key != nil {
+			cfg.DPoPKey = key.PrivateKey()
+		}
+	}
+}
+
+// WithTLSClientAuth 使用 PKI 签发的 mTLS 客户端证书进行客户端认证（tls_client_auth，RFC 8705 2.1）
+// 未通过 WithHTTPClient 自定义 HTTP 客户端时，SDK 会创建携带该证书的 HTTP 客户端；此时 clientSecret 可传空字符串
+func WithTLSClientAuth(cert tls.Certificate) ClientOption {
+	return func(cfg *configx.Config) {
+		cfg.ClientAuthMethod = ClientAuthTLS
+		cfg.TLSClientCertificate = &cert
+	}
+}
+
+// WithSelfSignedTLSClientAuth 使用自签名 mTLS 客户端证书进行客户端认证（self_signed_tls_client_auth，RFC 8705 2.2）
+// 证书需预先注册到授权服务器（jwks / jwks_uri），其余行为与 WithTLSClientAuth 相同
+func WithSelfSignedTLSClientAuth(cert tls.Certificate) ClientOption {
+	return func(cfg *configx.Config) {
+		cfg.ClientAuthMethod = ClientAuthSelfSignedTLS
+		cfg.TLSClientCertificate = &cert
+	}
+}
+
+// WithTLSClientCertificate 在与授权服务器的 TLS 连接中出示客户端证书，但不改变客户端认证方式
+// 适用于使用其他认证方式（例如公开客户端）且需要证书绑定访问令牌的场景（RFC 8705 3）；
+// 仅在未通过 WithHTTPClient 自定义 HTTP 客户端时生效，配置后 mTLS 端点别名同样生效
+func WithTLSClientCertificate(cert tls.Certificate) ClientOption {
+	return func(cfg *configx.Config) {
+		cfg.TLSClientCertificate = &cert
+	}
+}
+
+// WithTLSRootCAs 设置校验授权服务器证书的根证书池，适用于使用私有 CA 的环境
+// 仅在未通过 WithHTTPClient 自定义 HTTP 客户端时生效
+func WithTLSRootCAs(pool *x509.CertPool) ClientOption {
+	return func(cfg *configx.Config) {
+		cfg.TLSRootCAs = pool
+	}
+}
+
+// WithMTLSEndpointAliases 设置 mTLS 端点别名（RFC 8705 5）
+// 授权服务器在元数据 mtls_endpoint_aliases 中为 mTLS 请求提供了单独的端点时使用；
+// 仅在客户端使用 mTLS（WithTLSClientAuth、WithSelfSignedTLSClientAuth、WithTLSClientCertificate）时生效，
+// 此时对应请求发往别名地址，未配置别名的端点仍使用 BackendBaseURL
+func WithMTLSEndpointAliases(aliases MTLSEndpointAliases) ClientOption {
+	return func(cfg *configx.Config) {
+		cfg.MTLSEndpointAliases = aliases.byPath()
+	}
+}
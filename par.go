@@ -0,0 +1,123 @@
+package goauthsdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// PushAuthorizationRequest 推送授权请求（PAR，RFC 9126）
+// 将完整的授权参数通过后端通道提交给授权服务器，换取一次性的 request_uri，
+// 再通过 BuildPushedAuthorizationURL 生成只包含 client_id 与 request_uri 的短授权地址，
+// 避免授权参数出现在浏览器地址栏与历史记录中
+//
+// 参数:
+//   - ctx: 上下文，用于控制请求超时等
+//   - state: 可选的状态参数，用于防止 CSRF 攻击
+//   - scope: 可选的权限范围，多个 scope 用空格分隔
+//   - opts: 可选的扩展参数，与 BuildAuthorizationURL 相同
+//
+// 示例用法:
+//
+//	par, err := client.PushAuthorizationRequest(ctx, state, "read write", goauthsdk.WithPrompt("consent"))
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	authURL, err := client.BuildPushedAuthorizationURL(par.RequestURI)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	http.Redirect(w, r, authURL, http.StatusFound)
+func (c *Client) PushAuthorizationRequest(ctx context.Context, state, scope string, opts ...AuthorizationOption) (*PushedAuthorizationResponse, error) {
+	// 构建并发送请求
+	req, err := buildPushedAuthorizationRequest(ctx, c, state, scope, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, body, err := doPushedAuthorizationRequest(c, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// 解析响应
+	return parsePushedAuthorizationResponse(resp, body)
+}
+
+// BuildPushedAuthorizationURL 使用 PushAuthorizationRequest 返回的 request_uri 构建前端授权地址
+// 地址只包含 client_id 与 request_uri，其余参数由授权服务器根据 request_uri 取回；
+// 也适用于由客户端托管 BuildRequestObject 结果的 request_uri（JAR 引用传递）
+func (c *Client) BuildPushedAuthorizationURL(requestURI string) (string, error) {
+	if requestURI == "" {
+		return "", fmt.Errorf("request_uri is required")
+	}
+
+	// 构造前端授权确认页地址
+	u, err := url.Parse(c.cfg.FrontendBaseURL + "/oauth/authorize")
+	if err != nil {
+		return "", fmt.Errorf("parse frontend base url: %w", err)
+	}
+
+	// 构建 query 参数
+	q := url.Values{}
+	q.Set("client_id", c.cfg.ClientID)
+	q.Set("request_uri", requestURI)
+
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// buildPushedAuthorizationRequest 构建推送授权请求的 HTTP 请求
+func buildPushedAuthorizationRequest(ctx context.Context, c *Client, state, scope string, opts []AuthorizationOption) (*http.Request, error) {
+	// 构建请求 URL
+	parURL := c.endpointURL(pushedAuthorizationEndpointPath)
+
+	// 构建表单参数（与授权地址的 query 参数相同）
+	formData, err := c.buildAuthorizationQuery(state, scope, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// 创建 HTTP 请求（附加客户端认证信息）
+	req, err := newClientAuthRequest(ctx, c, parURL, formData)
+	if err != nil {
+		return nil, fmt.Errorf("create pushed authorization request: %w", err)
+	}
+
+	return req, nil
+}
+
+// doPushedAuthorizationRequest 发送推送授权请求并返回响应与响应体
+// 启用 DPoP 时附加 DPoP 证明，使授权码绑定到 DPoP 密钥（RFC 9449 10.1）
+func doPushedAuthorizationRequest(c *Client, req *http.Request) (*http.Response, []byte, error) {
+	return doDPoPRequest(c, req)
+}
+
+// parsePushedAuthorizationResponse 解析推送授权响应
+// 响应格式：{ "code": 0, "message": "...", "data": { "request_uri": "...", "expires_in": 60 } }
+// RFC 9126 规定成功状态码为 201，同时兼容 200
+func parsePushedAuthorizationResponse(resp *http.Response, body []byte) (*PushedAuthorizationResponse, error) {
+	// 非 2xx：统一走 decodeAPIError
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return nil, decodeAPIError(resp, body)
+	}
+
+	// 解析响应
+	var apiResp apiCodeResponse[PushedAuthorizationResponse]
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return nil, fmt.Errorf("parse pushed authorization response: %w", err)
+	}
+
+	// 检查业务是否成功（code == 0 表示成功）
+	if apiResp.Code != 0 {
+		return nil, newBusinessError(resp.StatusCode, apiResp.Code, apiResp.Message)
+	}
+
+	if apiResp.Data.RequestURI == "" {
+		return nil, fmt.Errorf("parse pushed authorization response: request_uri is missing")
+	}
+
+	return &apiResp.Data, nil
+}
@@ -0,0 +1,63 @@
+package goauthsdk
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"net/url"
+
+	"github.com/3086953492/goauthsdk/internal/cryptox"
+)
+
+// pkceMethodS256 是 SDK 使用的 code_challenge_method（RFC 7636 4.2）
+const pkceMethodS256 = "S256"
+
+// PKCE 授权码交换证明（RFC 7636）
+// 发起授权时通过 WithPKCE 发送 Challenge，交换令牌时通过 WithCodeVerifier 发送 Verifier；Verifier 需与 state 一起保存
+type PKCE struct {
+	Verifier  string // code_verifier，43 个字符的随机串
+	Challenge string // code_challenge，Verifier 的 SHA-256 摘要（base64url 无填充）
+	Method    string // code_challenge_method，固定为 S256
+}
+
+// GeneratePKCE 生成一组新的 PKCE 参数
+//
+// 示例用法:
+//
+//	pkce, err := goauthsdk.GeneratePKCE()
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	authURL, err := client.BuildAuthorizationURL(state, "openid", goauthsdk.WithPKCE(pkce))
+//	// 回调中
+//	token, err := client.ExchangeToken(ctx, code, goauthsdk.WithCodeVerifier(pkce.Verifier))
+func GeneratePKCE() (*PKCE, error) {
+	// 32 字节随机数编码后为 43 个字符，满足 RFC 7636 4.1 的长度要求
+	verifier, err := cryptox.RandomString(32)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	return &PKCE{
+		Verifier:  verifier,
+		Challenge: base64.RawURLEncoding.EncodeToString(sum[:]),
+		Method:    pkceMethodS256,
+	}, nil
+}
+
+// loopbackRedirectMatches 判断 redirectURI 是否为 allowed 仅端口不同的 loopback 地址（RFC 8252 7.3）
+func loopbackRedirectMatches(allowed, redirectURI string) bool {
+	a, err := url.Parse(allowed)
+	if err != nil || a.Scheme != "http" || !isLoopbackIP(a.Hostname()) {
+		return false
+	}
+	r, err := url.Parse(redirectURI)
+	if err != nil || r.Scheme != "http" {
+		return false
+	}
+	return r.Hostname() == a.Hostname() && r.Path == a.Path && r.RawQuery == a.RawQuery && r.Fragment == ""
+}
+
+// isLoopbackIP 判断主机是否为 loopback IP 字面量；RFC 8252 8.3 不推荐使用 localhost
+func isLoopbackIP(host string) bool {
+	return host == "127.0.0.1" || host == "::1"
+}
@@ -0,0 +1,297 @@
+package goauthsdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/3086953492/goauthsdk/internal/httpx"
+)
+
+// protectedResourceWellKnownPath 是受保护资源元数据的 well-known 路径（RFC 9728 3）
+const protectedResourceWellKnownPath = "/.well-known/oauth-protected-resource"
+
+// ErrResourceMetadataMismatch 表示获取到的受保护资源元数据中的 resource 与请求的资源不一致（RFC 9728 3.3）
+var ErrResourceMetadataMismatch = errors.New("protected resource metadata does not match the requested resource")
+
+// ProtectedResourceMetadata 受保护资源元数据（RFC 9728 2）
+// 资源服务器通过 ProtectedResourceMetadataHandler 发布，客户端通过 DiscoverProtectedResource 获取
+type ProtectedResourceMetadata struct {
+	Resource               string   `json:"resource"`                           // 资源标识（https 绝对 URI，不含 query 与 fragment）
+	AuthorizationServers   []string `json:"authorization_servers,omitempty"`    // 接受其令牌的授权服务器标识（issuer）
+	ScopesSupported        []string `json:"scopes_supported,omitempty"`         // 访问该资源可用的 scope
+	BearerMethodsSupported []string `json:"bearer_methods_supported,omitempty"` // 令牌传递方式：header、body、query
+	ResourceName           string   `json:"resource_name,omitempty"`            // 展示给用户的资源名称
+	ResourceDocumentation  string   `json:"resource_documentation,omitempty"`   // 开发者文档地址
+
+	TLSClientCertificateBoundAccessTokens bool     `json:"tls_client_certificate_bound_access_tokens,omitempty"` // 是否要求证书绑定令牌（RFC 8705）
+	DPoPBoundAccessTokensRequired         bool     `json:"dpop_bound_access_tokens_required,omitempty"`          // 是否要求 DPoP 绑定令牌（RFC 9449）
+	AuthorizationDetailsTypesSupported    []string `json:"authorization_details_types_supported,omitempty"`      // 支持的授权详情类型（RFC 9396）
+}
+
+// ProtectedResourceMetadataURL 返回资源标识对应的元数据地址（RFC 9728 3.1）
+// 例如 https://api.example.com/v1 对应 https://api.example.com/.well-known/oauth-protected-resource/v1
+func ProtectedResourceMetadataURL(resource string) (string, error) {
+	u, err := parseResourceIdentifier(resource)
+	if err != nil {
+		return "", err
+	}
+	path := strings.TrimSuffix(u.EscapedPath(), "/")
+	return u.Scheme + "://" + u.Host + protectedResourceWellKnownPath + path, nil
+}
+
+// parseResourceIdentifier 校验资源标识：https 绝对 URI，且不含 query 与 fragment（RFC 9728 1.2）
+// 为便于本地开发，loopback 地址允许使用 http
+func parseResourceIdentifier(resource string) (*url.URL, error) {
+	u, err := url.Parse(resource)
+	if err != nil || !u.IsAbs() || u.Host == "" {
+		return nil, fmt.Errorf("resource must be an absolute uri: %s", resource)
+	}
+	if u.Scheme != "https" && !(u.Scheme == "http" && isLoopbackHost(u.Hostname())) {
+		return nil, fmt.Errorf("resource must use https: %s", resource)
+	}
+	if u.RawQuery != "" || u.Fragment != "" || u.RawFragment != "" {
+		return nil, fmt.Errorf("resource must not contain a query or fragment: %s", resource)
+	}
+	return u, nil
+}
+
+// isLoopbackHost 判断主机名是否为 loopback 地址
+func isLoopbackHost(host string) bool {
+	return host == "localhost" || host == "127.0.0.1" || host == "::1"
+}
+
+// ProtectedResourceMetadataHandler 发布受保护资源元数据（RFC 9728 3）
+type ProtectedResourceMetadataHandler struct {
+	body []byte
+	path string
+}
+
+// NewProtectedResourceMetadataHandler 创建受保护资源元数据处理器
+// 处理器需注册在 Path() 返回的路径上；资源服务器返回 401 时可通过 ResourceMetadataChallenge 告知客户端元数据地址
+//
+// 参数:
+//   - metadata: 资源元数据，Resource 必填，AuthorizationServers 至少包含一个授权服务器
+//
+// 示例用法:
+//
+//	handler, err := goauthsdk.NewProtectedResourceMetadataHandler(goauthsdk.ProtectedResourceMetadata{
+//	    Resource:             "https://api.example.com",
+//	    AuthorizationServers: []string{"https://auth.example.com"},
+//	    ScopesSupported:      []string{"orders:read", "orders:write"},
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	mux.Handle(handler.Path(), handler)
+func NewProtectedResourceMetadataHandler(metadata ProtectedResourceMetadata) (*ProtectedResourceMetadataHandler, error) {
+	metadataURL, err := ProtectedResourceMetadataURL(metadata.Resource)
+	if err != nil {
+		return nil, err
+	}
+	if len(metadata.AuthorizationServers) == 0 {
+		return nil, fmt.Errorf("at least one authorization server is required")
+	}
+	for _, issuer := range metadata.AuthorizationServers {
+		if u, err := url.Parse(issuer); err != nil || !u.IsAbs() {
+			return nil, fmt.Errorf("authorization server must be an absolute uri: %s", issuer)
+		}
+	}
+	if len(metadata.BearerMethodsSupported) == 0 {
+		metadata.BearerMethodsSupported = []string{"header"}
+	}
+
+	body, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("encode protected resource metadata: %w", err)
+	}
+	u, _ := url.Parse(metadataURL)
+	return &ProtectedResourceMetadataHandler{body: body, path: u.Path}, nil
+}
+
+// Path 返回处理器应注册的路径，例如 /.well-known/oauth-protected-resource
+func (h *ProtectedResourceMetadataHandler) Path() string {
+	return h.path
+}
+
+// ServeHTTP 以 application/json 返回元数据
+func (h *ProtectedResourceMetadataHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodGet {
+		_, _ = w.Write(h.body)
+	}
+}
+
+// ResourceMetadataChallenge 构建携带 resource_metadata 参数的 WWW-Authenticate 质询（RFC 9728 5.1）
+// 资源服务器在令牌缺失或无效而返回 401 时使用，scope 为访问该资源所需的 scope（可为空）
+//
+// 示例用法:
+//
+//	w.Header().Set("WWW-Authenticate", goauthsdk.ResourceMetadataChallenge(metadataURL, "orders:read"))
+//	w.WriteHeader(http.StatusUnauthorized)
+func ResourceMetadataChallenge(metadataURL, scope string) string {
+	return formatWWWAuthenticate("Bearer", []string{"resource_metadata", "scope"}, map[string]string{
+		"resource_metadata": metadataURL,
+		"scope":             scope,
+	})
+}
+
+// ProtectedResourceDiscovery 是受保护资源发现的结果
+type ProtectedResourceDiscovery struct {
+	Metadata            *ProtectedResourceMetadata // 资源元数据
+	AuthorizationServer string                     // 选定的授权服务器标识
+	Scopes              []string                   // 访问资源应申请的 scope：质询中的 scope 优先，否则为 ScopesSupported
+}
+
+// DiscoverProtectedResource 根据资源服务器 401 响应中的 WWW-Authenticate 发现资源元数据（RFC 9728 5）
+// 从 resource_metadata 参数获取元数据，校验其 resource 与请求地址匹配，并选出授权服务器与 scope：
+// 配置了 WithIssuer 时要求元数据包含该授权服务器，否则使用第一个
+//
+// RFC 9728 3.3 要求 resource 与请求地址完全相同；此处放宽为前缀匹配：resourceURL 与 resource 同源，
+// 且路径等于 resource 的路径或位于其下（按路径段比较，/api 不匹配 /apiv2），查询参数不参与比较。
+// 这样整个 API 只需发布一个以 API 根地址为 resource 的元数据，访问 /api/orders/1 触发的质询同样可以发现；
+// 需要严格匹配时，调用方可自行比较 discovery.Metadata.Resource 与请求地址
+//
+// 参数:
+//   - ctx: 上下文
+//   - resourceURL: 触发 401 的资源请求地址
+//   - wwwAuthenticate: 401 响应的 WWW-Authenticate 头部
+//
+// 示例用法:
+//
+//	resp, _, err := client.DoResourceRequest(req, accessToken)
+//	if err == nil && resp.StatusCode == http.StatusUnauthorized {
+//	    discovery, err := client.DiscoverProtectedResource(ctx, req.URL.String(), resp.Header.Get("WWW-Authenticate"))
+//	    if err != nil {
+//	        return err
+//	    }
+//	    scope := strings.Join(discovery.Scopes, " ")
+//	    authURL, err := client.BuildAuthorizationURL(state, scope, goauthsdk.WithResource(discovery.Metadata.Resource))
+//	}
+func (c *Client) DiscoverProtectedResource(ctx context.Context, resourceURL, wwwAuthenticate string) (*ProtectedResourceDiscovery, error) {
+	var metadataURL, scope string
+	for _, challenge := range parseWWWAuthenticate(wwwAuthenticate) {
+		if v := challenge.params["resource_metadata"]; v != "" {
+			metadataURL, scope = v, challenge.params["scope"]
+			break
+		}
+	}
+	if metadataURL == "" {
+		return nil, fmt.Errorf("www-authenticate does not contain resource_metadata")
+	}
+
+	metadata, err := c.FetchProtectedResourceMetadata(ctx, metadataURL)
+	if err != nil {
+		return nil, err
+	}
+	if !resourceMatches(metadata.Resource, resourceURL) {
+		return nil, fmt.Errorf("%w: %s", ErrResourceMetadataMismatch, metadata.Resource)
+	}
+
+	discovery := &ProtectedResourceDiscovery{Metadata: metadata, Scopes: strings.Fields(scope)}
+	if len(discovery.Scopes) == 0 {
+		discovery.Scopes = metadata.ScopesSupported
+	}
+	switch {
+	case c.cfg.Issuer != "":
+		if !slices.Contains(metadata.AuthorizationServers, c.cfg.Issuer) {
+			return nil, fmt.Errorf("resource %s does not accept tokens from issuer %s", metadata.Resource, c.cfg.Issuer)
+		}
+		discovery.AuthorizationServer = c.cfg.Issuer
+	case len(metadata.AuthorizationServers) > 0:
+		discovery.AuthorizationServer = metadata.AuthorizationServers[0]
+	default:
+		return nil, fmt.Errorf("protected resource metadata does not list any authorization server")
+	}
+	return discovery, nil
+}
+
+// resourceMatches 判断请求地址是否属于资源标识：同源，且路径等于资源路径或位于其下
+// 相对 RFC 9728 3.3 的完全相等有所放宽，见 DiscoverProtectedResource
+func resourceMatches(resource, requestURL string) bool {
+	r, err := url.Parse(resource)
+	if err != nil {
+		return false
+	}
+	u, err := url.Parse(requestURL)
+	if err != nil {
+		return false
+	}
+	if !strings.EqualFold(r.Scheme, u.Scheme) || !strings.EqualFold(r.Host, u.Host) {
+		return false
+	}
+	base := strings.TrimSuffix(r.Path, "/")
+	return u.Path == base || base == "" || strings.HasPrefix(u.Path, base+"/")
+}
+
+// FetchProtectedResourceMetadata 获取受保护资源元数据
+// 响应为 RFC 9728 定义的 JSON 对象（不使用 goauth 的 {code,message,data} 包装）
+//
+// 参数:
+//   - ctx: 上下文
+//   - metadataURL: 元数据地址，通常来自 ProtectedResourceMetadataURL 或 WWW-Authenticate 的 resource_metadata 参数
+func (c *Client) FetchProtectedResourceMetadata(ctx context.Context, metadataURL string) (*ProtectedResourceMetadata, error) {
+	req, err := buildProtectedResourceMetadataRequest(ctx, metadataURL)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, body, err := doProtectedResourceMetadataRequest(c, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseProtectedResourceMetadataResponse(resp, body)
+}
+
+// buildProtectedResourceMetadataRequest 构建获取资源元数据的 HTTP 请求
+func buildProtectedResourceMetadataRequest(ctx context.Context, metadataURL string) (*http.Request, error) {
+	u, err := url.Parse(metadataURL)
+	if err != nil || !u.IsAbs() {
+		return nil, fmt.Errorf("resource metadata url must be an absolute uri: %s", metadataURL)
+	}
+	if u.Scheme != "https" && !(u.Scheme == "http" && isLoopbackHost(u.Hostname())) {
+		return nil, fmt.Errorf("resource metadata url must use https: %s", metadataURL)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, metadataURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create resource metadata request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	return req, nil
+}
+
+// doProtectedResourceMetadataRequest 发送资源元数据请求并返回响应与响应体
+func doProtectedResourceMetadataRequest(c *Client, req *http.Request) (*http.Response, []byte, error) {
+	return httpx.Do(c.cfg.HTTPClient, req)
+}
+
+// parseProtectedResourceMetadataResponse 解析资源元数据响应
+func parseProtectedResourceMetadataResponse(resp *http.Response, body []byte) (*ProtectedResourceMetadata, error) {
+	// 非 2xx：统一走 decodeAPIError
+	if resp.StatusCode != http.StatusOK {
+		return nil, decodeAPIError(resp, body)
+	}
+
+	var metadata ProtectedResourceMetadata
+	if err := json.Unmarshal(body, &metadata); err != nil {
+		return nil, fmt.Errorf("parse resource metadata response: %w", err)
+	}
+	if _, err := parseResourceIdentifier(metadata.Resource); err != nil {
+		return nil, fmt.Errorf("parse resource metadata response: %w", err)
+	}
+	return &metadata, nil
+}
@@ -0,0 +1,84 @@
+package goauthsdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDiscoverProtectedResourceMatchesResource(t *testing.T) {
+	var resource string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, protectedResourceWellKnownPath) {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(ProtectedResourceMetadata{
+			Resource:             resource,
+			AuthorizationServers: []string{"https://auth.example.com"},
+			ScopesSupported:      []string{"orders:read"},
+		})
+	}))
+	defer srv.Close()
+
+	client, err := NewClient("https://portal.example.com", "https://auth.example.com", "client-1", "client-secret",
+		"https://portal.example.com/callback")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		resource    string
+		resourceURL string
+		wantErr     bool
+	}{
+		// RFC 9728 3.3 的完全相等
+		{"identical", srv.URL + "/api", srv.URL + "/api", false},
+		{"identical root", srv.URL, srv.URL, false},
+
+		// 放宽的前缀匹配
+		{"sub path", srv.URL + "/api", srv.URL + "/api/orders/1", false},
+		{"trailing slash resource", srv.URL + "/api/", srv.URL + "/api/orders", false},
+		{"query ignored", srv.URL + "/api", srv.URL + "/api/orders?page=2", false},
+		{"root resource", srv.URL, srv.URL + "/api/orders", false},
+
+		// 不匹配
+		{"sibling path", srv.URL + "/api", srv.URL + "/apiv2/orders", true},
+		{"parent path", srv.URL + "/api/orders", srv.URL + "/api", true},
+		{"other host", srv.URL + "/api", "https://api.example.com/api", true},
+		{"other scheme", srv.URL + "/api", strings.Replace(srv.URL, "http://", "https://", 1) + "/api", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource = tt.resource
+			metadataURL, err := ProtectedResourceMetadataURL(tt.resource)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			discovery, err := client.DiscoverProtectedResource(context.Background(), tt.resourceURL,
+				ResourceMetadataChallenge(metadataURL, ""))
+			if tt.wantErr {
+				if !errors.Is(err, ErrResourceMetadataMismatch) {
+					t.Fatalf("got %v, want ErrResourceMetadataMismatch", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if discovery.Metadata.Resource != tt.resource || discovery.AuthorizationServer != "https://auth.example.com" {
+				t.Errorf("discovery = %+v", discovery)
+			}
+			if len(discovery.Scopes) != 1 || discovery.Scopes[0] != "orders:read" {
+				t.Errorf("scopes = %v", discovery.Scopes)
+			}
+		})
+	}
+}
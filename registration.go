@@ -0,0 +1,293 @@
+package goauthsdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/3086953492/goauthsdk/internal/configx"
+	"github.com/3086953492/goauthsdk/internal/httpx"
+)
+
+// registrationEndpointPath 是动态客户端注册端点路径（RFC 7591 3）
+const registrationEndpointPath = "/api/v1/oauth/register"
+
+// RegistrationClient 是动态客户端注册与管理的客户端（RFC 7591 / RFC 7592）
+// 注册发生在获得 client_id 之前，因此独立于 Client 使用
+type RegistrationClient struct {
+	cfg configx.Config
+}
+
+// NewRegistrationClient 创建动态客户端注册客户端
+//
+// 参数:
+//   - backendBaseURL: goauth 后端服务基础地址，例如 https://auth.example.com
+//   - opts: 可选配置，支持 WithHTTPClient、WithTLSClientAuth、WithTLSRootCAs 等与 HTTP 传输相关的选项
+func NewRegistrationClient(backendBaseURL string, opts ...ClientOption) (*RegistrationClient, error) {
+	cfg := configx.Config{BackendBaseURL: backendBaseURL}
+
+	// 应用可选配置
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if cfg.BackendBaseURL == "" {
+		return nil, fmt.Errorf("backend_base_url is required")
+	}
+	configx.Normalize(&cfg)
+
+	return &RegistrationClient{cfg: cfg}, nil
+}
+
+// RegisterClient 注册新客户端（RFC 7591 3.1）
+//
+// 参数:
+//   - ctx: 上下文，用于控制请求超时等
+//   - initialAccessToken: 授权服务器签发的初始访问令牌；服务端允许开放注册时可传空字符串
+//   - metadata: 客户端元数据
+//
+// 示例用法:
+//
+//	reg, err := registration.RegisterClient(ctx, initialAccessToken, goauthsdk.ClientMetadata{
+//	    ClientName:   "tenant-a portal",
+//	    RedirectURIs: []string{"https://tenant-a.example.com/callback"},
+//	    GrantTypes:   []string{"authorization_code", "refresh_token"},
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	// 持久化 reg（尤其是 ClientSecret、RegistrationAccessToken、RegistrationClientURI）后创建 Client
+//	client, err := reg.NewClient("https://portal.example.com", "https://auth.example.com")
+func (r *RegistrationClient) RegisterClient(ctx context.Context, initialAccessToken string, metadata ClientMetadata) (*ClientRegistration, error) {
+	// 构建并发送请求
+	req, err := buildRegistrationRequest(ctx, "POST", r.cfg.BackendBaseURL+registrationEndpointPath, initialAccessToken, metadata)
+	if err != nil {
+		return nil, fmt.Errorf("create register client request: %w", err)
+	}
+
+	resp, body, err := doRegistrationRequest(r, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// 解析响应
+	return parseRegistrationResponse(resp, body)
+}
+
+// ReadClient 读取已注册客户端的当前配置（RFC 7592 2.1）
+// reg 至少需要包含 RegistrationClientURI 与 RegistrationAccessToken；
+// 响应未返回 registration_access_token、registration_client_uri 或 client_secret 时沿用 reg 中的值
+func (r *RegistrationClient) ReadClient(ctx context.Context, reg *ClientRegistration) (*ClientRegistration, error) {
+	if err := validateRegistrationManagement(reg); err != nil {
+		return nil, err
+	}
+
+	// 构建并发送请求
+	req, err := buildRegistrationRequest(ctx, "GET", reg.RegistrationClientURI, reg.RegistrationAccessToken, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create read client request: %w", err)
+	}
+
+	resp, body, err := doRegistrationRequest(r, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// 解析响应
+	current, err := parseRegistrationResponse(resp, body)
+	if err != nil {
+		return nil, err
+	}
+	return current.carryForward(reg), nil
+}
+
+// UpdateClient 使用 reg 中的元数据整体替换已注册客户端的配置（RFC 7592 2.2）
+// 请求体包含 client_id、client_secret 与全部元数据；未设置的元数据字段会被服务端视为删除，
+// 因此应先通过 ReadClient 获取当前配置再修改；响应未返回的管理凭据与 client_secret 沿用 reg 中的值
+func (r *RegistrationClient) UpdateClient(ctx context.Context, reg *ClientRegistration) (*ClientRegistration, error) {
+	if err := validateRegistrationManagement(reg); err != nil {
+		return nil, err
+	}
+
+	// RFC 7592 2.2：请求体不得包含 registration_access_token、registration_client_uri 及签发时间字段
+	payload := struct {
+		ClientMetadata
+		ClientID     string `json:"client_id"`
+		ClientSecret string `json:"client_secret,omitempty"`
+	}{
+		ClientMetadata: reg.ClientMetadata,
+		ClientID:       reg.ClientID,
+		ClientSecret:   reg.ClientSecret,
+	}
+
+	// 构建并发送请求
+	req, err := buildRegistrationRequest(ctx, "PUT", reg.RegistrationClientURI, reg.RegistrationAccessToken, payload)
+	if err != nil {
+		return nil, fmt.Errorf("create update client request: %w", err)
+	}
+
+	resp, body, err := doRegistrationRequest(r, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// 解析响应
+	updated, err := parseRegistrationResponse(resp, body)
+	if err != nil {
+		return nil, err
+	}
+	return updated.carryForward(reg), nil
+}
+
+// DeleteClient 注销已注册客户端（RFC 7592 2.3）
+// 成功后 client_id、client_secret 与 registration_access_token 均失效
+func (r *RegistrationClient) DeleteClient(ctx context.Context, reg *ClientRegistration) error {
+	if err := validateRegistrationManagement(reg); err != nil {
+		return err
+	}
+
+	// 构建并发送请求
+	req, err := buildRegistrationRequest(ctx, "DELETE", reg.RegistrationClientURI, reg.RegistrationAccessToken, nil)
+	if err != nil {
+		return fmt.Errorf("create delete client request: %w", err)
+	}
+
+	resp, body, err := doRegistrationRequest(r, req)
+	if err != nil {
+		return err
+	}
+
+	// 成功：204 No Content（兼容 200）
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return decodeAPIError(resp, body)
+	}
+	return nil
+}
+
+// NewClient 使用注册结果创建 Client
+// RedirectURIs 的第一个地址作为默认 redirectURI，其余地址加入 WithAllowedRedirectURIs 白名单；
+// TokenEndpointAuthMethod 为 client_secret_* 或 none（公开客户端）时自动设置对应认证方式。
+// 使用 private_key_jwt 或 mTLS 认证时，需通过 opts 传入 WithPrivateKeyJWT、WithTLSClientAuth 等选项
+//
+// 参数:
+//   - frontendBaseURL: 前端站点基础地址
+//   - backendBaseURL: goauth 后端服务基础地址
+//   - opts: 其他可选配置，在根据注册结果推导的配置之后应用
+func (reg *ClientRegistration) NewClient(frontendBaseURL, backendBaseURL string, opts ...ClientOption) (*Client, error) {
+	if len(reg.RedirectURIs) == 0 {
+		return nil, fmt.Errorf("redirect_uris is required")
+	}
+
+	var derived []ClientOption
+	if len(reg.RedirectURIs) > 1 {
+		derived = append(derived, WithAllowedRedirectURIs(reg.RedirectURIs[1:]...))
+	}
+	switch reg.TokenEndpointAuthMethod {
+	case ClientAuthSecretBasic, ClientAuthSecretPost, ClientAuthSecretJWT, ClientAuthNone:
+		derived = append(derived, WithClientAuthMethod(reg.TokenEndpointAuthMethod))
+	}
+
+	return NewClient(
+		frontendBaseURL,
+		backendBaseURL,
+		reg.ClientID,
+		reg.ClientSecret,
+		reg.RedirectURIs[0],
+		append(derived, opts...)...,
+	)
+}
+
+// carryForward 沿用 prev 中响应未返回的管理凭据与客户端密钥
+// RFC 7592 允许服务端在读取与更新响应中省略未变化的 registration_access_token 与 client_secret，
+// 直接使用响应会导致调用方丢失后续管理客户端所需的凭据
+func (reg *ClientRegistration) carryForward(prev *ClientRegistration) *ClientRegistration {
+	if reg.RegistrationAccessToken == "" {
+		reg.RegistrationAccessToken = prev.RegistrationAccessToken
+	}
+	if reg.RegistrationClientURI == "" {
+		reg.RegistrationClientURI = prev.RegistrationClientURI
+	}
+	if reg.ClientSecret == "" && reg.ClientID == prev.ClientID {
+		reg.ClientSecret = prev.ClientSecret
+		if reg.ClientSecretExpiresAt == 0 {
+			reg.ClientSecretExpiresAt = prev.ClientSecretExpiresAt
+		}
+	}
+	return reg
+}
+
+// validateRegistrationManagement 校验管理已注册客户端所需的字段
+func validateRegistrationManagement(reg *ClientRegistration) error {
+	if reg == nil || reg.RegistrationClientURI == "" {
+		return fmt.Errorf("registration_client_uri is required")
+	}
+	if reg.RegistrationAccessToken == "" {
+		return fmt.Errorf("registration_access_token is required")
+	}
+	return nil
+}
+
+// buildRegistrationRequest 构建注册相关的 HTTP 请求
+// payload 为 nil 时不发送请求体；accessToken 为空时不设置 Authorization
+func buildRegistrationRequest(ctx context.Context, method, endpoint, accessToken string, payload any) (*http.Request, error) {
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("encode client metadata: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	// 创建 HTTP 请求
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+
+	// 设置请求头
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+	if accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+
+	return req, nil
+}
+
+// doRegistrationRequest 发送注册相关请求并返回响应与响应体
+func doRegistrationRequest(r *RegistrationClient, req *http.Request) (*http.Response, []byte, error) {
+	return httpx.Do(r.cfg.HTTPClient, req)
+}
+
+// parseRegistrationResponse 解析注册相关响应
+// 响应格式：{ "code": 0, "message": "...", "data": { "client_id": "...", ... } }
+// RFC 7591 规定注册成功状态码为 201，读取与更新为 200
+func parseRegistrationResponse(resp *http.Response, body []byte) (*ClientRegistration, error) {
+	// 非 2xx：统一走 decodeAPIError
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return nil, decodeAPIError(resp, body)
+	}
+
+	// 解析响应
+	var apiResp apiCodeResponse[ClientRegistration]
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return nil, fmt.Errorf("parse client registration response: %w", err)
+	}
+
+	// 检查业务是否成功（code == 0 表示成功）
+	if apiResp.Code != 0 {
+		return nil, newBusinessError(resp.StatusCode, apiResp.Code, apiResp.Message)
+	}
+
+	if apiResp.Data.ClientID == "" {
+		return nil, fmt.Errorf("parse client registration response: client_id is missing")
+	}
+
+	return &apiResp.Data, nil
+}
@@ -0,0 +1,149 @@
+package goauthsdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newRegistrationServer 返回模拟注册接口的测试服务
+// 注册响应包含完整凭据；读取与更新响应按 RFC 7592 省略 client_secret 与管理凭据
+func newRegistrationServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reg ClientRegistration
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == registrationEndpointPath:
+			if err := json.NewDecoder(r.Body).Decode(&reg.ClientMetadata); err != nil {
+				t.Errorf("decode metadata: %v", err)
+			}
+			reg.ClientID = "client-1"
+			if reg.TokenEndpointAuthMethod != ClientAuthNone {
+				reg.ClientSecret = "secret-1"
+				reg.ClientSecretExpiresAt = 1700000000
+			}
+			reg.RegistrationAccessToken = "rat-1"
+			reg.RegistrationClientURI = srv.URL + registrationEndpointPath + "/client-1"
+			w.WriteHeader(http.StatusCreated)
+		case r.URL.Path == registrationEndpointPath+"/client-1":
+			if got := r.Header.Get("Authorization"); got != "Bearer rat-1" {
+				t.Errorf("Authorization = %q", got)
+			}
+			if r.Method == http.MethodPut {
+				if err := json.NewDecoder(r.Body).Decode(&reg); err != nil {
+					t.Errorf("decode update: %v", err)
+				}
+				reg.ClientSecret = ""
+			} else {
+				reg.ClientID = "client-1"
+				reg.RedirectURIs = []string{"https://app.example.com/callback"}
+			}
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "message": "success", "data": reg})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestClientRegistrationNewClient(t *testing.T) {
+	srv := newRegistrationServer(t)
+	rc, err := NewRegistrationClient(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		authMethod string
+		wantSecret string
+	}{
+		{"public client", ClientAuthNone, ""},
+		{"confidential client", ClientAuthSecretPost, "secret-1"},
+		{"default auth method", "", "secret-1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reg, err := rc.RegisterClient(context.Background(), "", ClientMetadata{
+				RedirectURIs:            []string{"https://app.example.com/callback", "http://127.0.0.1/callback"},
+				TokenEndpointAuthMethod: tt.authMethod,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			client, err := reg.NewClient("https://portal.example.com", srv.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			wantMethod := tt.authMethod
+			if wantMethod == "" {
+				wantMethod = ClientAuthSecretBasic
+			}
+			if client.cfg.ClientAuthMethod != wantMethod {
+				t.Errorf("auth method = %q, want %q", client.cfg.ClientAuthMethod, wantMethod)
+			}
+			if client.cfg.ClientSecret != tt.wantSecret {
+				t.Errorf("client secret = %q, want %q", client.cfg.ClientSecret, tt.wantSecret)
+			}
+			if client.cfg.RedirectURI != "https://app.example.com/callback" {
+				t.Errorf("redirect uri = %q", client.cfg.RedirectURI)
+			}
+			if err := client.validateRedirectURI("http://127.0.0.1/callback"); err != nil {
+				t.Errorf("additional redirect uri rejected: %v", err)
+			}
+		})
+	}
+}
+
+func TestRegistrationClientCarriesCredentialsForward(t *testing.T) {
+	srv := newRegistrationServer(t)
+	rc, err := NewRegistrationClient(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reg, err := rc.RegisterClient(context.Background(), "", ClientMetadata{
+		RedirectURIs: []string{"https://app.example.com/callback"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	read, err := rc.ReadClient(context.Background(), reg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	updated, err := rc.UpdateClient(context.Background(), read)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, got := range map[string]*ClientRegistration{"read": read, "update": updated} {
+		if got.ClientSecret != "secret-1" || got.ClientSecretExpiresAt != 1700000000 {
+			t.Errorf("%s: client secret = %q (expires %d), want carried forward", name, got.ClientSecret, got.ClientSecretExpiresAt)
+		}
+		if got.RegistrationAccessToken != "rat-1" || got.RegistrationClientURI != reg.RegistrationClientURI {
+			t.Errorf("%s: management credentials not carried forward: %+v", name, got)
+		}
+	}
+
+	// 沿用的凭据可继续用于创建客户端与管理操作
+	client, err := updated.NewClient("https://portal.example.com", srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.cfg.ClientSecret != "secret-1" {
+		t.Errorf("client secret = %q", client.cfg.ClientSecret)
+	}
+
+	// client_id 变化时不沿用旧密钥
+	other := &ClientRegistration{ClientID: "client-2"}
+	if other.carryForward(reg).ClientSecret != "" {
+		t.Error("client secret carried forward across client_id")
+	}
+}
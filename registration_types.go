@@ -0,0 +1,46 @@
+package goauthsdk
+
+// ClientMetadata 客户端元数据（RFC 7591 2）
+// 用于 RegisterClient 注册新客户端，以及 UpdateClient 更新已注册客户端
+type ClientMetadata struct {
+	RedirectURIs            []string `json:"redirect_uris,omitempty"`              // 回调地址白名单，第一个作为 NewClient 的默认 redirectURI
+	TokenEndpointAuthMethod string   `json:"token_endpoint_auth_method,omitempty"` // 客户端认证方式，例如 client_secret_basic、private_key_jwt
+	GrantTypes              []string `json:"grant_types,omitempty"`                // 允许的授权模式，例如 authorization_code、refresh_token
+	ResponseTypes           []string `json:"response_types,omitempty"`             // 允许的 response_type，例如 code
+	ClientName              string   `json:"client_name,omitempty"`                // 客户端名称，展示在授权确认页
+	ClientURI               string   `json:"client_uri,omitempty"`                 // 客户端主页地址
+	LogoURI                 string   `json:"logo_uri,omitempty"`                   // 客户端 Logo 地址
+	Scope                   string   `json:"scope,omitempty"`                      // 允许申请的 scope，多个以空格分隔
+	Contacts                []string `json:"contacts,omitempty"`                   // 联系人邮箱
+	TosURI                  string   `json:"tos_uri,omitempty"`                    // 服务条款地址
+	PolicyURI               string   `json:"policy_uri,omitempty"`                 // 隐私政策地址
+	JWKSURI                 string   `json:"jwks_uri,omitempty"`                   // 客户端公钥集地址（private_key_jwt、JAR 使用）
+	JWKS                    any      `json:"jwks,omitempty"`                       // 客户端公钥集（与 jwks_uri 二选一）
+	SoftwareID              string   `json:"software_id,omitempty"`                // 软件标识
+	SoftwareVersion         string   `json:"software_version,omitempty"`           // 软件版本
+	SoftwareStatement       string   `json:"software_statement,omitempty"`         // 软件声明（签名 JWT）
+
+	TLSClientAuthSubjectDN string `json:"tls_client_auth_subject_dn,omitempty"` // tls_client_auth 期望的证书主题（RFC 8705 2.1.2）
+	DPoPBoundAccessTokens  bool   `json:"dpop_bound_access_tokens,omitempty"`   // 是否要求访问令牌绑定 DPoP（RFC 9449 5.2）
+
+	PostLogoutRedirectURIs            []string `json:"post_logout_redirect_uris,omitempty"`            // 登出后回跳地址白名单（OIDC RP-Initiated Logout）
+	BackChannelLogoutURI              string   `json:"backchannel_logout_uri,omitempty"`               // 后端通道登出地址（OIDC Back-Channel Logout）
+	BackChannelLogoutSessionRequired  bool     `json:"backchannel_logout_session_required,omitempty"`  // 是否要求登出令牌携带 sid
+	FrontChannelLogoutURI             string   `json:"frontchannel_logout_uri,omitempty"`              // 前端通道登出地址（OIDC Front-Channel Logout）
+	FrontChannelLogoutSessionRequired bool     `json:"frontchannel_logout_session_required,omitempty"` // 是否要求前端通道登出携带 iss 与 sid
+}
+
+// ClientRegistration 客户端注册结果（RFC 7591 3.2.1 / RFC 7592 3）
+// 由 RegisterClient、ReadClient、UpdateClient 返回；RegistrationAccessToken 与 RegistrationClientURI
+// 用于后续管理该客户端，需与 ClientSecret 一样妥善保存
+type ClientRegistration struct {
+	ClientMetadata
+
+	ClientID              string `json:"client_id"`                          // 客户端 ID
+	ClientSecret          string `json:"client_secret,omitempty"`            // 客户端密钥（公钥认证方式下为空）
+	ClientIDIssuedAt      int64  `json:"client_id_issued_at,omitempty"`      // client_id 签发时间（Unix 时间戳，秒）
+	ClientSecretExpiresAt int64  `json:"client_secret_expires_at,omitempty"` // client_secret 过期时间（Unix 时间戳，秒），0 表示不过期
+
+	RegistrationAccessToken string `json:"registration_access_token,omitempty"` // 管理该客户端使用的访问令牌
+	RegistrationClientURI   string `json:"registration_client_uri,omitempty"`   // 管理该客户端的接口地址
+}
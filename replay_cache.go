@@ -0,0 +1,62 @@
+package goauthsdk
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// ReplayCache 记录一次性标识（例如 DPoP 证明或登出令牌的 jti），用于检测重放
+// 多实例部署时应使用共享存储（如 Redis SETNX）实现，实现需保证并发安全
+type ReplayCache interface {
+	// Add 记录 key，expiresAt 之后可以遗忘
+	// 返回 true 表示首次出现；返回 false 表示 key 已存在（重放）
+	Add(ctx context.Context, key string, expiresAt time.Time) (bool, error)
+
+	// Delete 删除 key，用于处理失败时撤销 Add 的记录，使同一标识可以被重试
+	Delete(ctx context.Context, key string) error
+}
+
+// MemoryReplayCache 是基于内存的 ReplayCache 实现，适用于单实例部署与测试
+type MemoryReplayCache struct {
+	mu        sync.Mutex
+	entries   map[string]time.Time
+	lastSweep time.Time
+}
+
+// NewMemoryReplayCache 创建内存重放缓存
+func NewMemoryReplayCache() *MemoryReplayCache {
+	return &MemoryReplayCache{entries: make(map[string]time.Time)}
+}
+
+// Add 实现 ReplayCache；写入时按 sweepInterval 惰性清理已过期条目，避免缓存无限增长
+func (m *MemoryReplayCache) Add(ctx context.Context, key string, expiresAt time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(m.lastSweep) >= sweepInterval {
+		for k, exp := range m.entries {
+			if !now.Before(exp) {
+				delete(m.entries, k)
+			}
+		}
+		m.lastSweep = now
+	}
+
+	// 未到清理时间的过期条目视为不存在
+	if exp, ok := m.entries[key]; ok && now.Before(exp) {
+		return false, nil
+	}
+	m.entries[key] = expiresAt
+	return true, nil
+}
+
+// Delete 实现 ReplayCache
+func (m *MemoryReplayCache) Delete(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.entries, key)
+	return nil
+}
@@ -0,0 +1,123 @@
+package goauthsdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/3086953492/goauthsdk/internal/cryptox"
+	"github.com/3086953492/goauthsdk/internal/jwtx"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// requestObjectType 是请求对象的 JOSE typ 头部（RFC 9101 10.8）
+	requestObjectType = "oauth-authz-req+jwt"
+
+	// requestObjectLifetime 是请求对象的有效期
+	requestObjectLifetime = 5 * time.Minute
+)
+
+// BuildRequestObject 构建签名的授权请求对象（JAR，RFC 9101）
+// 适用于通过引用传递（request_uri）的场景：将返回的 JWT 托管在授权服务器可访问的地址上，
+// 再用该地址调用 BuildPushedAuthorizationURL 生成授权地址；直接传值时使用 WithSignedRequestObject 即可
+//
+// 参数:
+//   - state: 可选的状态参数，用于防止 CSRF 攻击
+//   - scope: 可选的权限范围，多个 scope 用空格分隔
+//   - opts: 可选的扩展参数，与 BuildAuthorizationURL 相同
+//
+// 示例用法:
+//
+//	requestObject, err := client.BuildRequestObject(state, "read")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	// 将 requestObject 以 application/oauth-authz-req+jwt 托管在 https://yourapp.com/requests/<id>
+//	authURL, err := client.BuildPushedAuthorizationURL("https://yourapp.com/requests/" + id)
+func (c *Client) BuildRequestObject(state, scope string, opts ...AuthorizationOption) (string, error) {
+	q, err := c.buildAuthorizationQuery(state, scope, opts)
+	if err != nil {
+		return "", err
+	}
+	if requestObject := q.Get("request"); requestObject != "" {
+		return requestObject, nil
+	}
+	return c.signRequestObject(q)
+}
+
+// signRequestObject 将授权参数签名为请求对象
+// iss 为 client_id，aud 优先使用 issuer，否则使用 BackendBaseURL
+func (c *Client) signRequestObject(params url.Values) (string, error) {
+	jti, err := cryptox.RandomString(16)
+	if err != nil {
+		return "", err
+	}
+
+	claims := jwt.MapClaims{}
+	for name, values := range params {
+		switch len(values) {
+		case 0:
+		case 1:
+			claims[name] = requestObjectClaim(name, values[0])
+		default:
+			// 多值参数（例如 resource）使用 JSON 数组
+			claims[name] = values
+		}
+	}
+
+	audience := c.cfg.Issuer
+	if audience == "" {
+		audience = c.cfg.BackendBaseURL
+	}
+	now := time.Now()
+	claims["iss"] = c.cfg.ClientID
+	claims["aud"] = audience
+	claims["jti"] = jti
+	claims["iat"] = now.Unix()
+	claims["nbf"] = now.Unix()
+	claims["exp"] = now.Add(requestObjectLifetime).Unix()
+
+	var key any = []byte(c.cfg.ClientSecret)
+	if c.cfg.RequestObjectKey != nil {
+		key = c.cfg.RequestObjectKey
+	} else if c.cfg.ClientSecret == "" {
+		return "", fmt.Errorf("request object signing key or client_secret is required")
+	}
+
+	headers := map[string]any{"typ": requestObjectType}
+	if c.cfg.RequestObjectKeyID != "" {
+		headers["kid"] = c.cfg.RequestObjectKeyID
+	}
+
+	requestObject, err := jwtx.Sign(claims, key, headers)
+	if err != nil {
+		return "", fmt.Errorf("sign request object: %w", err)
+	}
+	return requestObject, nil
+}
+
+// requestObjectClaim 将授权参数转换为请求对象中的声明值
+// max_age 使用数字，claims 使用 JSON 对象（OIDC Core 6.1），authorization_details 使用 JSON 数组（RFC 9396 3），
+// 其余参数保持字符串
+func requestObjectClaim(name, value string) any {
+	switch name {
+	case "max_age":
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return n
+		}
+	case "claims":
+		var obj map[string]any
+		if err := json.Unmarshal([]byte(value), &obj); err == nil {
+			return obj
+		}
+	case "authorization_details":
+		var details []any
+		if err := json.Unmarshal([]byte(value), &details); err == nil {
+			return details
+		}
+	}
+	return value
+}
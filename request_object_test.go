@@ -0,0 +1,160 @@
+package goauthsdk
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// parseRequestObject 使用 key 校验请求对象签名并返回声明
+func parseRequestObject(t *testing.T, requestObject string, key any) (jwt.MapClaims, map[string]any) {
+	t.Helper()
+	claims := jwt.MapClaims{}
+	token, err := jwt.ParseWithClaims(requestObject, claims, func(*jwt.Token) (any, error) { return key, nil },
+		jwt.WithValidMethods([]string{"ES256", "HS256"}))
+	if err != nil {
+		t.Fatalf("parse request object: %v", err)
+	}
+	return claims, token.Header
+}
+
+func TestBuildRequestObjectSignedWithKey(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := NewClient("https://portal.example.com", "https://auth.example.com", "client-1", "",
+		"https://app.example.com/callback",
+		WithPrivateKeyJWT(key, "client-key"),
+		WithRequestObjectSigningKey(key, "request-key"),
+		WithIssuer("https://issuer.example.com"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkce, err := GeneratePKCE()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	before := time.Now().Unix()
+	requestObject, err := client.BuildRequestObject("state-1", "openid read",
+		WithPKCE(pkce),
+		WithPrompt("login", "consent"),
+		WithMaxAge(5*time.Minute),
+		WithResource("https://api.example.com/a", "https://api.example.com/b"),
+		WithClaims(map[string]any{"userinfo": map[string]any{"email": nil}}),
+		WithAuthorizationDetails(AuthorizationDetail{Type: "payment_initiation", Actions: []string{"initiate"}}),
+		WithAuthorizationParam("acr_values", "urn:mace:incommon:iap:silver"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claims, header := parseRequestObject(t, requestObject, &key.PublicKey)
+	if header["typ"] != requestObjectType || header["kid"] != "request-key" {
+		t.Errorf("header = %v", header)
+	}
+
+	want := map[string]any{
+		"iss":                   "client-1",
+		"aud":                   "https://issuer.example.com",
+		"client_id":             "client-1",
+		"response_type":         "code",
+		"redirect_uri":          "https://app.example.com/callback",
+		"scope":                 "openid read",
+		"state":                 "state-1",
+		"code_challenge":        pkce.Challenge,
+		"code_challenge_method": "S256",
+		"prompt":                "login consent",
+		"max_age":               float64(300),
+		"resource":              []any{"https://api.example.com/a", "https://api.example.com/b"},
+		"claims":                map[string]any{"userinfo": map[string]any{"email": nil}},
+		"authorization_details": []any{map[string]any{"type": "payment_initiation", "actions": []any{"initiate"}}},
+		"acr_values":            "urn:mace:incommon:iap:silver",
+	}
+	for name, value := range want {
+		if !reflect.DeepEqual(claims[name], value) {
+			t.Errorf("claim %s = %#v, want %#v", name, claims[name], value)
+		}
+	}
+
+	if jti, _ := claims["jti"].(string); jti == "" {
+		t.Error("jti is empty")
+	}
+	exp, err := claims.GetExpirationTime()
+	if err != nil || exp == nil {
+		t.Fatalf("exp: %v", err)
+	}
+	if lifetime := exp.Unix() - before; lifetime < int64(requestObjectLifetime.Seconds()) || lifetime > int64(requestObjectLifetime.Seconds())+5 {
+		t.Errorf("exp - now = %ds, want about %s", lifetime, requestObjectLifetime)
+	}
+
+	another, err := client.BuildRequestObject("state-1", "openid read")
+	if err != nil {
+		t.Fatal(err)
+	}
+	anotherClaims, _ := parseRequestObject(t, another, &key.PublicKey)
+	if anotherClaims["jti"] == claims["jti"] {
+		t.Error("jti reused across request objects")
+	}
+}
+
+func TestWithSignedRequestObjectUsesClientSecret(t *testing.T) {
+	const secret = "client-secret-with-enough-length"
+	client, err := NewClient("https://portal.example.com", "https://auth.example.com", "client-1", secret,
+		"https://app.example.com/callback")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authURL, err := client.BuildAuthorizationURL("state-1", "read",
+		WithSignedRequestObject(),
+		WithLoginHint("alice@example.com"),
+		WithResponseMode("form_post"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := u.Query()
+	if len(query) != 2 || query.Get("client_id") != "client-1" || query.Get("request") == "" {
+		t.Fatalf("authorization query = %v, want only client_id and request", query)
+	}
+
+	claims, header := parseRequestObject(t, query.Get("request"), []byte(secret))
+	if header["alg"] != "HS256" {
+		t.Errorf("alg = %v, want HS256", header["alg"])
+	}
+	want := map[string]any{
+		"iss":           "client-1",
+		"aud":           "https://auth.example.com",
+		"client_id":     "client-1",
+		"response_type": "code",
+		"redirect_uri":  "https://app.example.com/callback",
+		"scope":         "read",
+		"state":         "state-1",
+		"login_hint":    "alice@example.com",
+		"response_mode": "form_post",
+	}
+	for name, value := range want {
+		if claims[name] != value {
+			t.Errorf("claim %s = %#v, want %#v", name, claims[name], value)
+		}
+	}
+	if jti, _ := claims["jti"].(string); jti == "" {
+		t.Error("jti is empty")
+	}
+	if _, err := claims.GetExpirationTime(); err != nil {
+		t.Errorf("exp: %v", err)
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
-	"strings"
 
 	"github.com/3086953492/goauthsdk/internal/httpx"
 )
@@ -77,7 +76,7 @@ func (c *Client) RevokeTokenWithHint(ctx context.Context, token, tokenTypeHint s
 // buildRevokeRequest 构建撤销请求的 HTTP 请求
 func buildRevokeRequest(ctx context.Context, c *Client, token, tokenTypeHint string) (*http.Request, error) {
 	// 构建请求 URL
-	revokeURL := c.cfg.BackendBaseURL + "/api/v1/oauth/revoke"
+	revokeURL := c.endpointURL(revocationEndpointPath)
 
 	// 构建表单参数
 	formData := url.Values{}
@@ -86,18 +85,12 @@ func buildRevokeRequest(ctx context.Context, c *Client, token, tokenTypeHint str
 		formData.Set("token_type_hint", tokenTypeHint)
 	}
 
-	// 创建 HTTP 请求
-	req, err := http.NewRequestWithContext(ctx, "POST", revokeURL, strings.NewReader(formData.Encode()))
+	// 创建 HTTP 请求（附加客户端认证信息）
+	req, err := newClientAuthRequest(ctx, c, revokeURL, formData)
 	if err != nil {
 		return nil, fmt.Errorf("create revoke request: %w", err)
 	}
 
-	// 设置 Content-Type
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-
-	// 设置 Basic Auth（client_id 和 client_secret）
-	req.SetBasicAuth(c.cfg.ClientID, c.cfg.ClientSecret)
-
 	return req, nil
 }
 
@@ -0,0 +1,117 @@
+package session
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/3086953492/goauthsdk/internal/cryptox"
+)
+
+// maxChunkSize 是单个 Cookie 值的最大长度
+// 浏览器通常限制单个 Cookie（含名称与属性）不超过 4096 字节，这里预留属性空间
+const maxChunkSize = 3800
+
+// maxChunks 是单个会话允许拆分的最大 Cookie 数量，防止异常数据写爆请求头
+const maxChunks = 10
+
+// cookieCodec 负责将会话载荷加密、分块写入 Cookie，并从 Cookie 中还原
+type cookieCodec struct {
+	keys     *cryptox.KeyRing
+	name     string
+	path     string
+	domain   string
+	secure   bool
+	sameSite http.SameSite
+}
+
+// chunkName 返回第 i 个分块的 Cookie 名称：第 0 块使用原名，其余追加 "_<i>"
+func (c *cookieCodec) chunkName(i int) string {
+	if i == 0 {
+		return c.name
+	}
+	return c.name + "_" + strconv.Itoa(i)
+}
+
+// read 读取并解密 Cookie 中的会话载荷
+// Cookie 不存在或解密失败时返回 ErrNoSession
+func (c *cookieCodec) read(r *http.Request) ([]byte, error) {
+	var sb strings.Builder
+	for i := 0; i < maxChunks; i++ {
+		cookie, err := r.Cookie(c.chunkName(i))
+		if err != nil {
+			break
+		}
+		sb.WriteString(cookie.Value)
+	}
+	if sb.Len() == 0 {
+		return nil, ErrNoSession
+	}
+
+	ciphertext, err := base64.RawURLEncoding.DecodeString(sb.String())
+	if err != nil {
+		return nil, ErrNoSession
+	}
+	// Cookie 名称作为附加认证数据，防止密文被挪用到其他 Cookie
+	plaintext, err := c.keys.Open(ciphertext, []byte(c.name))
+	if err != nil {
+		return nil, ErrNoSession
+	}
+	return plaintext, nil
+}
+
+// write 加密载荷并分块写入 Cookie，同时清理请求中多余的旧分块
+func (c *cookieCodec) write(w http.ResponseWriter, r *http.Request, payload []byte, maxAge int) error {
+	ciphertext, err := c.keys.Seal(payload, []byte(c.name))
+	if err != nil {
+		return fmt.Errorf("encrypt session cookie: %w", err)
+	}
+	value := base64.RawURLEncoding.EncodeToString(ciphertext)
+
+	n := (len(value) + maxChunkSize - 1) / maxChunkSize
+	if n > maxChunks {
+		return fmt.Errorf("session cookie too large: %d bytes", len(value))
+	}
+
+	for i := 0; i < n; i++ {
+		end := (i + 1) * maxChunkSize
+		if end > len(value) {
+			end = len(value)
+		}
+		http.SetCookie(w, c.newCookie(c.chunkName(i), value[i*maxChunkSize:end], maxAge))
+	}
+	c.clearFrom(w, r, n)
+	return nil
+}
+
+// clear 删除请求中携带的全部会话 Cookie 分块
+func (c *cookieCodec) clear(w http.ResponseWriter, r *http.Request) {
+	c.clearFrom(w, r, 0)
+}
+
+// clearFrom 删除请求中序号不小于 start 的会话 Cookie 分块
+func (c *cookieCodec) clearFrom(w http.ResponseWriter, r *http.Request, start int) {
+	for i := start; i < maxChunks; i++ {
+		name := c.chunkName(i)
+		if _, err := r.Cookie(name); err != nil {
+			continue
+		}
+		http.SetCookie(w, c.newCookie(name, "", -1))
+	}
+}
+
+// newCookie 按配置的属性创建 Cookie
+func (c *cookieCodec) newCookie(name, value string, maxAge int) *http.Cookie {
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     c.path,
+		Domain:   c.domain,
+		MaxAge:   maxAge,
+		Secure:   c.secure,
+		HttpOnly: true,
+		SameSite: c.sameSite,
+	}
+}
@@ -0,0 +1,367 @@
+package session
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/3086953492/goauthsdk"
+	"github.com/3086953492/goauthsdk/internal/cryptox"
+)
+
+// touchInterval 是滑动续期的最小写回间隔，避免每个请求都重写 Cookie/存储
+const touchInterval = time.Minute
+
+// refreshReuseWindow 是刷新结果的复用窗口
+// 同一刷新令牌在窗口内的并发刷新直接复用首次结果，避免服务端轮换刷新令牌后二次使用旧令牌
+const refreshReuseWindow = 30 * time.Second
+
+// Manager 管理已登录浏览器的会话
+// 会话通过 AEAD 加密的 Cookie 传递；访问令牌临近过期时自动调用 Client.RefreshToken 刷新
+type Manager struct {
+	client *goauthsdk.Client
+	cfg    config
+	codec  *cookieCodec
+	now    func() time.Time
+
+	mu        sync.Mutex
+	locks     map[string]*refreshLock
+	refreshed map[string]refreshResult
+}
+
+// refreshLock 是按刷新令牌区分的互斥锁，refs 为等待者计数
+type refreshLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// refreshResult 是最近一次刷新的结果缓存
+type refreshResult struct {
+	token *goauthsdk.TokenResponse
+	at    time.Time
+}
+
+// NewManager 创建会话管理器
+//
+// 参数:
+//   - client: 用于刷新访问令牌的 goauth 客户端
+//   - keys: Cookie 加密密钥（每个 16/24/32 字节）；第一个用于加密，全部用于解密，
+//     轮换密钥时把新密钥放在首位并保留旧密钥，直到旧会话全部过期
+//   - opts: 可选配置，见 WithStore、WithIdleTimeout 等
+//
+// 示例用法:
+//
+//	mgr, err := session.NewManager(client, [][]byte{key},
+//	    session.WithStore(session.NewMemoryStore()),
+//	)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func NewManager(client *goauthsdk.Client, keys [][]byte, opts ...Option) (*Manager, error) {
+	if client == nil {
+		return nil, fmt.Errorf("client is required")
+	}
+
+	cfg := config{
+		cookieName:      DefaultCookieName,
+		cookiePath:      "/",
+		secure:          true,
+		sameSite:        http.SameSiteLaxMode,
+		idleTimeout:     DefaultIdleTimeout,
+		absoluteTimeout: DefaultAbsoluteTimeout,
+		refreshBefore:   DefaultRefreshBefore,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if cfg.cookieName == "" {
+		return nil, fmt.Errorf("cookie_name is required")
+	}
+	if cfg.idleTimeout <= 0 || cfg.absoluteTimeout <= 0 {
+		return nil, fmt.Errorf("idle_timeout and absolute_timeout must be positive")
+	}
+
+	keyRing, err := cryptox.NewKeyRing(keys...)
+	if err != nil {
+		return nil, fmt.Errorf("create session key ring: %w", err)
+	}
+
+	return &Manager{
+		client: client,
+		cfg:    cfg,
+		codec: &cookieCodec{
+			keys:     keyRing,
+			name:     cfg.cookieName,
+			path:     cfg.cookiePath,
+			domain:   cfg.cookieDomain,
+			secure:   cfg.secure,
+			sameSite: cfg.sameSite,
+		},
+		now:       time.Now,
+		locks:     make(map[string]*refreshLock),
+		refreshed: make(map[string]refreshResult),
+	}, nil
+}
+
+// Create 为登录成功的用户创建新会话并写入 Cookie
+// 通常在回调中 ExchangeToken 成功后调用；请求中已有的旧会话会被销毁，防止会话固定攻击
+//
+// 示例用法:
+//
+//	token, err := client.ExchangeToken(r.Context(), code)
+//	if err != nil {
+//	    // handle error
+//	}
+//	info, _ := client.UserInfo(r.Context(), token.AccessToken.AccessToken)
+//	if _, err := mgr.Create(w, r, token, info); err != nil {
+//	    // handle error
+//	}
+func (m *Manager) Create(w http.ResponseWriter, r *http.Request, token *goauthsdk.TokenResponse, info *goauthsdk.UserInfo) (*Session, error) {
+	if token == nil {
+		return nil, fmt.Errorf("token is required")
+	}
+
+	// 销毁旧会话（若存在）
+	if old, err := m.loadRaw(r); err == nil && m.cfg.store != nil {
+		if err := m.cfg.store.Delete(r.Context(), old.ID); err != nil {
+			return nil, fmt.Errorf("delete previous session: %w", err)
+		}
+	}
+
+	id, err := cryptox.RandomString(32)
+	if err != nil {
+		return nil, fmt.Errorf("generate session id: %w", err)
+	}
+
+	now := m.now()
+	s := &Session{
+		ID:           id,
+		UserInfo:     info,
+		CreatedAt:    now.Unix(),
+		LastActiveAt: now.Unix(),
+	}
+	s.setToken(token, now)
+
+	if err := m.Save(w, r, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Load 读取当前请求的会话
+// 会话有效时顺延空闲超时；访问令牌临近过期时自动刷新并写回
+//
+// 返回值:
+//   - *Session: 会话数据
+//   - error: 无会话返回 ErrNoSession；会话过期返回 ErrSessionExpired（Cookie 已被清除）；
+//     刷新失败返回包装后的刷新错误，可通过 errors.As 获取 *goauthsdk.APIError
+func (m *Manager) Load(w http.ResponseWriter, r *http.Request) (*Session, error) {
+	s, err := m.loadRaw(r)
+	if err != nil {
+		return nil, err
+	}
+
+	now := m.now()
+	if m.expired(s, now) {
+		if err := m.destroy(w, r, s.ID); err != nil {
+			return nil, err
+		}
+		return nil, ErrSessionExpired
+	}
+
+	dirty := false
+	if m.needsRefresh(s, now) {
+		if err := m.refresh(r.Context(), s); err != nil {
+			return nil, fmt.Errorf("refresh session token: %w", err)
+		}
+		dirty = true
+	}
+
+	// 滑动续期
+	if now.Unix()-s.LastActiveAt >= int64(touchInterval/time.Second) {
+		s.LastActiveAt = now.Unix()
+		dirty = true
+	}
+
+	if dirty {
+		if err := m.Save(w, r, s); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// Save 持久化会话并写入 Cookie
+// 修改会话（例如更新 UserInfo）后调用
+func (m *Manager) Save(w http.ResponseWriter, r *http.Request, s *Session) error {
+	ttl := m.remaining(s, m.now())
+	if ttl <= 0 {
+		return ErrSessionExpired
+	}
+
+	var payload []byte
+	if m.cfg.store != nil {
+		if err := m.cfg.store.Save(r.Context(), s, ttl); err != nil {
+			return fmt.Errorf("save session: %w", err)
+		}
+		payload = []byte(s.ID)
+	} else {
+		data, err := json.Marshal(s)
+		if err != nil {
+			return fmt.Errorf("encode session: %w", err)
+		}
+		payload = data
+	}
+
+	return m.codec.write(w, r, payload, int(ttl/time.Second))
+}
+
+// Destroy 销毁当前请求的会话并清除 Cookie
+// 请求中没有会话时不返回错误
+func (m *Manager) Destroy(w http.ResponseWriter, r *http.Request) error {
+	s, err := m.loadRaw(r)
+	if err != nil {
+		if errors.Is(err, ErrNoSession) {
+			m.codec.clear(w, r)
+			return nil
+		}
+		return err
+	}
+	return m.destroy(w, r, s.ID)
+}
+
+// destroy 删除服务端会话（若配置了 Store）并清除 Cookie
+func (m *Manager) destroy(w http.ResponseWriter, r *http.Request, id string) error {
+	if m.cfg.store != nil {
+		if err := m.cfg.store.Delete(r.Context(), id); err != nil {
+			return fmt.Errorf("delete session: %w", err)
+		}
+	}
+	m.codec.clear(w, r)
+	return nil
+}
+
+// loadRaw 从 Cookie（及 Store）读取会话，不做过期与刷新处理
+func (m *Manager) loadRaw(r *http.Request) (*Session, error) {
+	payload, err := m.codec.read(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if m.cfg.store != nil {
+		s, err := m.cfg.store.Load(r.Context(), string(payload))
+		if err != nil {
+			if errors.Is(err, ErrNoSession) {
+				return nil, ErrNoSession
+			}
+			return nil, fmt.Errorf("load session: %w", err)
+		}
+		return s, nil
+	}
+
+	var s Session
+	if err := json.Unmarshal(payload, &s); err != nil {
+		return nil, ErrNoSession
+	}
+	return &s, nil
+}
+
+// expired 判断会话是否超过空闲超时或绝对过期时间
+func (m *Manager) expired(s *Session, now time.Time) bool {
+	return m.remaining(s, now) <= 0
+}
+
+// remaining 返回会话剩余存活时间：取空闲超时与绝对过期的较早者
+func (m *Manager) remaining(s *Session, now time.Time) time.Duration {
+	absolute := time.Unix(s.CreatedAt, 0).Add(m.cfg.absoluteTimeout)
+	idle := time.Unix(s.LastActiveAt, 0).Add(m.cfg.idleTimeout)
+	deadline := absolute
+	if idle.Before(deadline) {
+		deadline = idle
+	}
+	return deadline.Sub(now)
+}
+
+// needsRefresh 判断访问令牌是否临近过期且可刷新
+func (m *Manager) needsRefresh(s *Session, now time.Time) bool {
+	if s.Token == nil || s.Token.RefreshToken.RefreshToken == "" {
+		return false
+	}
+	return now.Add(m.cfg.refreshBefore).Unix() >= s.AccessTokenExpiresAt
+}
+
+// refresh 使用刷新令牌换取新访问令牌并更新会话
+// 同一刷新令牌的并发刷新只会向服务端发起一次请求
+func (m *Manager) refresh(ctx context.Context, s *Session) error {
+	oldRefreshToken := s.Token.RefreshToken.RefreshToken
+	sum := sha256.Sum256([]byte(oldRefreshToken))
+	key := hex.EncodeToString(sum[:])
+
+	unlock := m.lock(key)
+	defer unlock()
+
+	if token, ok := m.recentRefresh(key); ok {
+		s.setToken(token, m.now())
+		return nil
+	}
+
+	token, err := m.client.RefreshToken(ctx, oldRefreshToken)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	m.refreshed[key] = refreshResult{token: token, at: m.now()}
+	m.mu.Unlock()
+
+	s.setToken(token, m.now())
+	return nil
+}
+
+// recentRefresh 返回复用窗口内的刷新结果，同时清理过期缓存
+func (m *Manager) recentRefresh(key string) (*goauthsdk.TokenResponse, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	for k, result := range m.refreshed {
+		if now.Sub(result.at) > refreshReuseWindow {
+			delete(m.refreshed, k)
+		}
+	}
+	result, ok := m.refreshed[key]
+	if !ok {
+		return nil, false
+	}
+	return result.token, true
+}
+
+// lock 获取 key 对应的互斥锁，返回释放函数
+func (m *Manager) lock(key string) func() {
+	m.mu.Lock()
+	l, ok := m.locks[key]
+	if !ok {
+		l = &refreshLock{}
+		m.locks[key] = l
+	}
+	l.refs++
+	m.mu.Unlock()
+
+	l.mu.Lock()
+	return func() {
+		l.mu.Unlock()
+		m.mu.Lock()
+		l.refs--
+		if l.refs == 0 {
+			delete(m.locks, key)
+		}
+		m.mu.Unlock()
+	}
+}
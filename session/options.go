@@ -0,0 +1,101 @@
+package session
+
+import (
+	"net/http"
+	"time"
+)
+
+const (
+	// DefaultCookieName 是默认的会话 Cookie 名称
+	DefaultCookieName = "goauth_session"
+
+	// DefaultIdleTimeout 是默认的空闲超时（滑动过期）
+	DefaultIdleTimeout = 24 * time.Hour
+
+	// DefaultAbsoluteTimeout 是默认的绝对过期时间（自创建起计算）
+	DefaultAbsoluteTimeout = 7 * 24 * time.Hour
+
+	// DefaultRefreshBefore 是访问令牌到期前多久触发自动刷新
+	DefaultRefreshBefore = time.Minute
+)
+
+// config 是 Manager 的内部配置
+type config struct {
+	store           Store
+	cookieName      string
+	cookiePath      string
+	cookieDomain    string
+	secure          bool
+	sameSite        http.SameSite
+	idleTimeout     time.Duration
+	absoluteTimeout time.Duration
+	refreshBefore   time.Duration
+}
+
+// Option 用于配置 Manager 的可选参数
+type Option func(*config)
+
+// WithStore 设置服务端会话存储
+// 设置后 Cookie 中仅保存加密的会话 ID；未设置时会话数据整体加密保存在 Cookie 中
+func WithStore(store Store) Option {
+	return func(cfg *config) {
+		cfg.store = store
+	}
+}
+
+// WithCookieName 设置会话 Cookie 名称，默认 DefaultCookieName
+// 会话过大时会拆分为 name、name_1、name_2 等多个 Cookie
+func WithCookieName(name string) Option {
+	return func(cfg *config) {
+		cfg.cookieName = name
+	}
+}
+
+// WithCookiePath 设置会话 Cookie 的 Path，默认 "/"
+func WithCookiePath(path string) Option {
+	return func(cfg *config) {
+		cfg.cookiePath = path
+	}
+}
+
+// WithCookieDomain 设置会话 Cookie 的 Domain，默认不设置（仅当前主机）
+func WithCookieDomain(domain string) Option {
+	return func(cfg *config) {
+		cfg.cookieDomain = domain
+	}
+}
+
+// WithSecureCookie 设置会话 Cookie 是否仅通过 HTTPS 发送，默认 true
+func WithSecureCookie(secure bool) Option {
+	return func(cfg *config) {
+		cfg.secure = secure
+	}
+}
+
+// WithSameSite 设置会话 Cookie 的 SameSite 属性，默认 http.SameSiteLaxMode
+func WithSameSite(sameSite http.SameSite) Option {
+	return func(cfg *config) {
+		cfg.sameSite = sameSite
+	}
+}
+
+// WithIdleTimeout 设置空闲超时：超过该时长无访问则会话失效，每次访问会顺延
+func WithIdleTimeout(d time.Duration) Option {
+	return func(cfg *config) {
+		cfg.idleTimeout = d
+	}
+}
+
+// WithAbsoluteTimeout 设置绝对过期时间：自会话创建起超过该时长必定失效，不随访问顺延
+func WithAbsoluteTimeout(d time.Duration) Option {
+	return func(cfg *config) {
+		cfg.absoluteTimeout = d
+	}
+}
+
+// WithRefreshBefore 设置访问令牌到期前多久自动调用 RefreshToken 刷新
+func WithRefreshBefore(d time.Duration) Option {
+	return func(cfg *config) {
+		cfg.refreshBefore = d
+	}
+}
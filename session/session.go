@@ -0,0 +1,104 @@
+package session
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/3086953492/goauthsdk"
+)
+
+var (
+	// ErrNoSession 表示请求中没有携带会话（或会话 Cookie 无法解密）
+	ErrNoSession = errors.New("session not found")
+
+	// ErrSessionExpired 表示会话已超过空闲超时或绝对过期时间
+	ErrSessionExpired = errors.New("session expired")
+)
+
+// Session 表示一个已登录浏览器的会话数据
+// 时间字段均为 Unix 时间戳（秒）
+type Session struct {
+	ID                   string                   `json:"id"`                      // 会话 ID
+	Token                *goauthsdk.TokenResponse `json:"token,omitempty"`         // 令牌信息（ExchangeToken / RefreshToken 的返回值）
+	UserInfo             *goauthsdk.UserInfo      `json:"user_info,omitempty"`     // 用户信息
+	AccessTokenExpiresAt int64                    `json:"access_token_expires_at"` // 访问令牌过期时间（Unix 时间戳，秒）
+	CreatedAt            int64                    `json:"created_at"`              // 会话创建时间（Unix 时间戳，秒）
+	LastActiveAt         int64                    `json:"last_active_at"`          // 最近活跃时间（Unix 时间戳，秒）
+}
+
+// AccessToken 返回会话中的访问令牌字符串，无令牌时返回空字符串
+func (s *Session) AccessToken() string {
+	if s.Token == nil {
+		return ""
+	}
+	return s.Token.AccessToken.AccessToken
+}
+
+// setToken 更新会话令牌，并根据 expires_in 计算访问令牌过期时间
+// 若新令牌未携带 refresh_token（服务端未轮换），保留原有刷新令牌
+func (s *Session) setToken(token *goauthsdk.TokenResponse, now time.Time) {
+	if token == nil {
+		return
+	}
+	next := *token
+	if next.RefreshToken.RefreshToken == "" && s.Token != nil {
+		next.RefreshToken = s.Token.RefreshToken
+	}
+	s.Token = &next
+	s.AccessTokenExpiresAt = now.Add(time.Duration(token.AccessToken.ExpiresIn) * time.Second).Unix()
+}
+
+// clone 返回会话的深拷贝，避免存储实现与调用方共享可变数据
+func (s *Session) clone() *Session {
+	c := *s
+	if s.Token != nil {
+		token := *s.Token
+		token.AuthorizationDetails = cloneAuthorizationDetails(s.Token.AuthorizationDetails)
+		c.Token = &token
+	}
+	if s.UserInfo != nil {
+		info := *s.UserInfo
+		c.UserInfo = &info
+	}
+	return &c
+}
+
+// cloneAuthorizationDetails 深拷贝授权详情，包括各字段的切片与 Extra 中的嵌套值
+func cloneAuthorizationDetails(details goauthsdk.AuthorizationDetails) goauthsdk.AuthorizationDetails {
+	if details == nil {
+		return nil
+	}
+	cloned := make(goauthsdk.AuthorizationDetails, len(details))
+	for i, d := range details {
+		d.Locations = slices.Clone(d.Locations)
+		d.Actions = slices.Clone(d.Actions)
+		d.DataTypes = slices.Clone(d.DataTypes)
+		d.Privileges = slices.Clone(d.Privileges)
+		if d.Extra != nil {
+			d.Extra = cloneJSONValue(d.Extra).(map[string]any)
+		}
+		cloned[i] = d
+	}
+	return cloned
+}
+
+// cloneJSONValue 深拷贝 JSON 解码得到的值（map[string]any、[]any 与标量）
+func cloneJSONValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		m := make(map[string]any, len(v))
+		for key, value := range v {
+			m[key] = cloneJSONValue(value)
+		}
+		return m
+	case []any:
+		a := make([]any, len(v))
+		for i, value := range v {
+			a[i] = cloneJSONValue(value)
+		}
+		return a
+	default:
+		return v
+	}
+}
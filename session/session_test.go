@@ -0,0 +1,310 @@
+package session
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/3086953492/goauthsdk"
+	"github.com/3086953492/goauthsdk/internal/cryptox"
+)
+
+// newTestKey 生成 32 字节的 Cookie 加密密钥
+func newTestKey(t *testing.T) []byte {
+	t.Helper()
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+// newTestCodec 创建使用给定密钥的 cookieCodec
+func newTestCodec(t *testing.T, name string, keys ...[]byte) *cookieCodec {
+	t.Helper()
+	keyRing, err := cryptox.NewKeyRing(keys...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &cookieCodec{keys: keyRing, name: name, path: "/", secure: true, sameSite: http.SameSiteLaxMode}
+}
+
+// requestWithCookies 返回携带响应中 Set-Cookie 的请求，MaxAge < 0 的 Cookie（删除）不携带
+func requestWithCookies(w *httptest.ResponseRecorder) *http.Request {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	for _, cookie := range w.Result().Cookies() {
+		if cookie.MaxAge >= 0 {
+			r.AddCookie(cookie)
+		}
+	}
+	return r
+}
+
+// newTestClient 创建指向 backend 的 goauthsdk.Client
+func newTestClient(t *testing.T, backend string) *goauthsdk.Client {
+	t.Helper()
+	client, err := goauthsdk.NewClient(backend, backend, "client-1", "client-secret", "https://app.example.com/callback")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestCookieCodecSealOpen(t *testing.T) {
+	key := newTestKey(t)
+	codec := newTestCodec(t, "sid", key)
+	payload := []byte(`{"id":"session-1"}`)
+
+	w := httptest.NewRecorder()
+	if err := codec.write(w, httptest.NewRequest(http.MethodGet, "/", nil), payload, 60); err != nil {
+		t.Fatal(err)
+	}
+	cookies := w.Result().Cookies()
+	if len(cookies) != 1 || !cookies[0].HttpOnly || !cookies[0].Secure || bytes.Contains([]byte(cookies[0].Value), []byte("session-1")) {
+		t.Fatalf("cookies = %+v, want one encrypted HttpOnly Secure cookie", cookies)
+	}
+
+	got, err := codec.read(requestWithCookies(w))
+	if err != nil || !bytes.Equal(got, payload) {
+		t.Fatalf("read = %q, %v; want %q", got, err, payload)
+	}
+
+	tests := []struct {
+		name   string
+		codec  *cookieCodec
+		mutate func(value string) string
+	}{
+		{"flipped byte", codec, func(v string) string {
+			b := []byte(v)
+			b[len(b)/2] ^= 1
+			return string(b)
+		}},
+		{"truncated", codec, func(v string) string { return v[:len(v)-4] }},
+		{"not base64", codec, func(v string) string { return v + "!" }},
+		{"other key", newTestCodec(t, "sid", newTestKey(t)), func(v string) string { return v }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.AddCookie(&http.Cookie{Name: "sid", Value: tt.mutate(cookies[0].Value)})
+			if _, err := tt.codec.read(r); !errors.Is(err, ErrNoSession) {
+				t.Errorf("read = %v, want ErrNoSession", err)
+			}
+		})
+	}
+
+	// Cookie 名称作为附加认证数据，密文不能挪用到其他名称的 Cookie
+	other := newTestCodec(t, "other", key)
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.AddCookie(&http.Cookie{Name: "other", Value: cookies[0].Value})
+	if _, err := other.read(r); !errors.Is(err, ErrNoSession) {
+		t.Errorf("read with other cookie name = %v, want ErrNoSession", err)
+	}
+}
+
+func TestCookieCodecChunks(t *testing.T) {
+	codec := newTestCodec(t, "sid", newTestKey(t))
+
+	// 随机内容无法压缩，base64 后约 3 个分块
+	raw := make([]byte, 4000)
+	if _, err := rand.Read(raw); err != nil {
+		t.Fatal(err)
+	}
+	payload := []byte(hex.EncodeToString(raw))
+
+	w := httptest.NewRecorder()
+	if err := codec.write(w, httptest.NewRequest(http.MethodGet, "/", nil), payload, 60); err != nil {
+		t.Fatal(err)
+	}
+	cookies := w.Result().Cookies()
+	if len(cookies) < 2 {
+		t.Fatalf("got %d cookies, want payload split into chunks", len(cookies))
+	}
+	for i, cookie := range cookies {
+		if cookie.Name != codec.chunkName(i) || len(cookie.Value) > maxChunkSize {
+			t.Errorf("chunk %d: name %s, %d bytes", i, cookie.Name, len(cookie.Value))
+		}
+	}
+	r := requestWithCookies(w)
+	got, err := codec.read(r)
+	if err != nil || !bytes.Equal(got, payload) {
+		t.Fatalf("read chunked payload: %v", err)
+	}
+
+	// 载荷变小后写入单个分块，并删除请求中多余的旧分块
+	w = httptest.NewRecorder()
+	if err := codec.write(w, r, []byte("small"), 60); err != nil {
+		t.Fatal(err)
+	}
+	deleted := 0
+	for _, cookie := range w.Result().Cookies() {
+		if cookie.MaxAge < 0 {
+			deleted++
+		}
+	}
+	if deleted != len(cookies)-1 {
+		t.Errorf("deleted %d stale chunks, want %d", deleted, len(cookies)-1)
+	}
+	if got, err := codec.read(requestWithCookies(w)); err != nil || string(got) != "small" {
+		t.Errorf("read after shrink = %q, %v", got, err)
+	}
+
+	// 超过 maxChunks 个分块时拒绝写入
+	tooLarge := make([]byte, maxChunkSize*maxChunks)
+	w = httptest.NewRecorder()
+	err = codec.write(w, httptest.NewRequest(http.MethodGet, "/", nil), tooLarge, 60)
+	if err == nil || !strings.Contains(err.Error(), "session cookie too large") {
+		t.Fatalf("write = %v, want too large error", err)
+	}
+	if len(w.Result().Cookies()) != 0 {
+		t.Error("cookies written for oversized session")
+	}
+}
+
+func TestManagerKeyRotation(t *testing.T) {
+	client := newTestClient(t, "https://auth.example.com")
+	oldKey, newKey := newTestKey(t), newTestKey(t)
+	token := &goauthsdk.TokenResponse{AccessToken: goauthsdk.AccessTokenInfo{AccessToken: "access", ExpiresIn: 3600}}
+
+	oldMgr, err := NewManager(client, [][]byte{oldKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := httptest.NewRecorder()
+	created, err := oldMgr.Create(w, httptest.NewRequest(http.MethodGet, "/", nil), token, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldCookie := requestWithCookies(w)
+
+	// 新密钥放在首位，旧密钥仍可解密已有会话
+	rotated, err := NewManager(client, [][]byte{newKey, oldKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := rotated.Load(httptest.NewRecorder(), oldCookie)
+	if err != nil || s.ID != created.ID {
+		t.Fatalf("load with rotated keys = %v, %v", s, err)
+	}
+
+	// 写回后使用新密钥加密，移除旧密钥后仍可读取
+	w = httptest.NewRecorder()
+	if err := rotated.Save(w, oldCookie, s); err != nil {
+		t.Fatal(err)
+	}
+	newOnly, err := NewManager(client, [][]byte{newKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, err := newOnly.Load(httptest.NewRecorder(), requestWithCookies(w)); err != nil || s.ID != created.ID {
+		t.Fatalf("load re-sealed session = %v, %v", s, err)
+	}
+	if _, err := newOnly.Load(httptest.NewRecorder(), oldCookie); !errors.Is(err, ErrNoSession) {
+		t.Errorf("load old cookie without old key = %v, want ErrNoSession", err)
+	}
+}
+
+func TestManagerConcurrentRefresh(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		// 保持请求进行中，确保其余并发刷新在此期间到达
+		time.Sleep(50 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"code":0,"message":"success","data":{` +
+			`"access_token":{"access_token":"access-2","expires_in":3600},` +
+			`"refresh_token":{"refresh_token":"refresh-2","expires_in":86400},"token_type":"Bearer"}}`))
+	}))
+	defer srv.Close()
+
+	mgr, err := NewManager(newTestClient(t, srv.URL), [][]byte{newTestKey(t)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := httptest.NewRecorder()
+	_, err = mgr.Create(w, httptest.NewRequest(http.MethodGet, "/", nil), &goauthsdk.TokenResponse{
+		AccessToken:  goauthsdk.AccessTokenInfo{AccessToken: "access-1", ExpiresIn: 0},
+		RefreshToken: goauthsdk.RefreshTokenInfo{RefreshToken: "refresh-1", ExpiresIn: 86400},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cookies := w.Result().Cookies()
+
+	const concurrency = 8
+	var wg sync.WaitGroup
+	for range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			for _, cookie := range cookies {
+				r.AddCookie(cookie)
+			}
+			s, err := mgr.Load(httptest.NewRecorder(), r)
+			if err != nil {
+				t.Errorf("Load: %v", err)
+				return
+			}
+			if s.AccessToken() != "access-2" || s.Token.RefreshToken.RefreshToken != "refresh-2" {
+				t.Errorf("token = %+v, want refreshed token", s.Token)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := calls.Load(); n != 1 {
+		t.Errorf("refresh endpoint called %d times, want 1", n)
+	}
+}
+
+func TestMemoryStoreClonesAuthorizationDetails(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	s := &Session{
+		ID: "session-1",
+		Token: &goauthsdk.TokenResponse{AuthorizationDetails: goauthsdk.AuthorizationDetails{{
+			Type:    "payment_initiation",
+			Actions: []string{"initiate"},
+			Extra:   map[string]any{"instructedAmount": map[string]any{"amount": "10.00"}},
+		}}},
+		UserInfo: &goauthsdk.UserInfo{},
+	}
+	if err := store.Save(ctx, s, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	// 修改调用方持有的会话不影响存储中的副本
+	s.Token.AuthorizationDetails[0].Actions[0] = "changed"
+	s.Token.AuthorizationDetails[0].Extra["instructedAmount"].(map[string]any)["amount"] = "99.00"
+
+	loaded, err := store.Load(ctx, "session-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	detail := loaded.Token.AuthorizationDetails[0]
+	if detail.Actions[0] != "initiate" || detail.Extra["instructedAmount"].(map[string]any)["amount"] != "10.00" {
+		t.Fatalf("stored detail was mutated: %+v", detail)
+	}
+
+	// 修改 Load 返回的会话同样不影响存储
+	detail.Actions[0] = "changed"
+	detail.Extra["instructedAmount"].(map[string]any)["amount"] = "99.00"
+	again, err := store.Load(ctx, "session-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.Token.AuthorizationDetails[0].Actions[0] != "initiate" ||
+		again.Token.AuthorizationDetails[0].Extra["instructedAmount"].(map[string]any)["amount"] != "10.00" {
+		t.Fatalf("loaded session shares data with store: %+v", again.Token.AuthorizationDetails[0])
+	}
+}
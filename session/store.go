@@ -0,0 +1,91 @@
+package session
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Store 是服务端会话存储接口
+// 配置 Store 后，Cookie 中仅保存加密后的会话 ID，会话数据保存在服务端
+//
+// 实现约定:
+//   - Load 在会话不存在或已过期时返回 ErrNoSession
+//   - Save 的 ttl 为会话剩余最长存活时间，实现应在到期后自动清理
+//   - Delete 对不存在的会话不返回错误
+type Store interface {
+	Load(ctx context.Context, id string) (*Session, error)
+	Save(ctx context.Context, s *Session, ttl time.Duration) error
+	Delete(ctx context.Context, id string) error
+}
+
+// sweepInterval 是 MemoryStore 清理过期会话的最小间隔
+const sweepInterval = time.Minute
+
+// MemoryStore 是基于内存的 Store 实现
+// 适用于单实例部署或测试；进程重启后会话全部丢失
+type MemoryStore struct {
+	mu        sync.Mutex
+	sessions  map[string]memoryEntry
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// memoryEntry 是 MemoryStore 中的会话条目
+type memoryEntry struct {
+	session   *Session
+	expiresAt time.Time
+}
+
+// NewMemoryStore 创建一个空的内存会话存储
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		sessions: make(map[string]memoryEntry),
+		now:      time.Now,
+	}
+}
+
+// Load 读取会话，不存在或已过期时返回 ErrNoSession
+func (m *MemoryStore) Load(ctx context.Context, id string) (*Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, ok := m.sessions[id]
+	if !ok {
+		return nil, ErrNoSession
+	}
+	if !m.now().Before(entry.expiresAt) {
+		delete(m.sessions, id)
+		return nil, ErrNoSession
+	}
+	return entry.session.clone(), nil
+}
+
+// Save 保存会话，ttl 到期后会话自动失效
+func (m *MemoryStore) Save(ctx context.Context, s *Session, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	m.sessions[s.ID] = memoryEntry{session: s.clone(), expiresAt: now.Add(ttl)}
+
+	// 惰性清理过期会话，避免内存无限增长
+	if now.Sub(m.lastSweep) >= sweepInterval {
+		for id, entry := range m.sessions {
+			if !now.Before(entry.expiresAt) {
+				delete(m.sessions, id)
+			}
+		}
+		m.lastSweep = now
+	}
+	return nil
+}
+
+// Delete 删除会话
+func (m *MemoryStore) Delete(ctx context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.sessions, id)
+	return nil
+}
@@ -0,0 +1,174 @@
+package goauthsdk
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// checkSessionPath 是授权服务器 OP iframe 的前端页面路径（OIDC Session Management 3.3）
+	checkSessionPath = "/oauth/check_session"
+
+	// DefaultSessionCheckInterval 是 RP iframe 轮询会话状态的默认间隔
+	DefaultSessionCheckInterval = 5 * time.Second
+)
+
+// CheckSessionIframeURL 返回授权服务器 OP iframe 地址（check_session_iframe）
+// RP iframe 通过 postMessage 向该页面发送 SessionCheckMessage 的结果，收到 changed、unchanged 或 error
+func (c *Client) CheckSessionIframeURL() string {
+	return c.cfg.FrontendBaseURL + checkSessionPath
+}
+
+// SessionCheckMessage 构建 RP iframe 发送给 OP iframe 的消息："client_id session_state"（OIDC Session Management 3.2）
+//
+// 参数:
+//   - sessionState: 授权回调中的 session_state，即 AuthorizationResponse.SessionState
+func (c *Client) SessionCheckMessage(sessionState string) string {
+	return c.cfg.ClientID + " " + sessionState
+}
+
+// SessionCheckHandler 渲染 RP iframe 页面（OIDC Session Management 3.2）
+// 页面内嵌 OP iframe 并定期发送会话检查消息；收到 changed 时将顶层窗口跳转到 changedURL，
+// 由应用重新发起 prompt=none 的授权请求或直接清理本地会话
+type SessionCheckHandler struct {
+	client       *Client
+	sessionState func(r *http.Request) string
+	changedURL   string
+	interval     time.Duration
+}
+
+// SessionCheckOption 用于配置 SessionCheckHandler 的可选参数
+type SessionCheckOption func(*SessionCheckHandler)
+
+// WithSessionCheckInterval 设置会话检查的轮询间隔，默认 DefaultSessionCheckInterval
+func WithSessionCheckInterval(d time.Duration) SessionCheckOption {
+	return func(h *SessionCheckHandler) {
+		h.interval = d
+	}
+}
+
+// NewSessionCheckHandler 创建 RP iframe 处理器
+// 在应用页面中以隐藏 iframe 加载该处理器，例如 <iframe src="/session-check" hidden></iframe>
+//
+// 参数:
+//   - client: Client
+//   - sessionState: 返回当前请求对应会话的 session_state，为空时页面不发起检查
+//   - changedURL: 会话状态变化时顶层窗口跳转的地址，必须是绝对路径或绝对 URL
+//   - opts: 可选配置
+//
+// 示例用法:
+//
+//	handler, err := goauthsdk.NewSessionCheckHandler(client,
+//	    func(r *http.Request) string {
+//	        // 登录回调时将 AuthorizationResponse.SessionState 保存在 Cookie 中
+//	        cookie, err := r.Cookie("session_state")
+//	        if err != nil {
+//	            return ""
+//	        }
+//	        return cookie.Value
+//	    },
+//	    "/logout",
+//	)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	mux.Handle("/session-check", handler)
+func NewSessionCheckHandler(client *Client, sessionState func(r *http.Request) string, changedURL string, opts ...SessionCheckOption) (*SessionCheckHandler, error) {
+	if client == nil {
+		return nil, fmt.Errorf("client is required")
+	}
+	if sessionState == nil {
+		return nil, fmt.Errorf("session state callback is required")
+	}
+	u, err := url.Parse(changedURL)
+	if err != nil || (!u.IsAbs() && !strings.HasPrefix(u.Path, "/")) || strings.HasPrefix(changedURL, "//") {
+		return nil, fmt.Errorf("changed url must be an absolute path or absolute url: %s", changedURL)
+	}
+
+	h := &SessionCheckHandler{
+		client:       client,
+		sessionState: sessionState,
+		changedURL:   changedURL,
+		interval:     DefaultSessionCheckInterval,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	if h.interval <= 0 {
+		h.interval = DefaultSessionCheckInterval
+	}
+	return h, nil
+}
+
+// sessionCheckPage 是 RP iframe 页面模板，html/template 负责脚本上下文中的转义
+var sessionCheckPage = template.Must(template.New("session_check").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>session check</title></head>
+<body>
+<iframe id="op" src="{{.OPFrameURL}}" hidden></iframe>
+<script>
+(function () {
+  var message = {{.Message}};
+  var opOrigin = {{.OPOrigin}};
+  var changedURL = {{.ChangedURL}};
+  var op = document.getElementById("op");
+  var timer = null;
+  function check() {
+    op.contentWindow.postMessage(message, opOrigin);
+  }
+  window.addEventListener("message", function (e) {
+    if (e.origin !== opOrigin) {
+      return;
+    }
+    if (e.data === "changed") {
+      clearInterval(timer);
+      window.top.location.href = changedURL;
+    }
+  }, false);
+  op.addEventListener("load", function () {
+    if (!message) {
+      return;
+    }
+    check();
+    timer = setInterval(check, {{.IntervalMillis}});
+  });
+})();
+</script>
+</body>
+</html>
+`))
+
+// ServeHTTP 渲染 RP iframe 页面
+func (h *SessionCheckHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	opFrameURL := h.client.CheckSessionIframeURL()
+	u, err := url.Parse(opFrameURL)
+	if err != nil {
+		http.Error(w, "invalid check session iframe url", http.StatusInternalServerError)
+		return
+	}
+
+	message := ""
+	if state := h.sessionState(r); state != "" {
+		message = h.client.SessionCheckMessage(state)
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	_ = sessionCheckPage.Execute(w, struct {
+		OPFrameURL     string
+		OPOrigin       string
+		Message        string
+		ChangedURL     string
+		IntervalMillis int64
+	}{
+		OPFrameURL:     opFrameURL,
+		OPOrigin:       u.Scheme + "://" + u.Host,
+		Message:        message,
+		ChangedURL:     h.changedURL,
+		IntervalMillis: h.interval.Milliseconds(),
+	})
+}
@@ -0,0 +1,233 @@
+package goauthsdk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/3086953492/goauthsdk/internal/jwtx"
+)
+
+// insufficientUserAuthentication 是 RFC 9470 定义的错误码
+const insufficientUserAuthentication = "insufficient_user_authentication"
+
+// AuthenticationContext 是令牌携带的用户认证信息（RFC 9470 / OIDC Core 2）
+type AuthenticationContext struct {
+	ACR      string    // 认证上下文等级，例如 mfa
+	AMR      []string  // 认证方式，例如 pwd、otp
+	AuthTime time.Time // 用户认证时间，令牌未携带 auth_time 时为零值
+}
+
+// AuthenticationContext 返回内省结果中的用户认证信息
+func (r *IntrospectionResponse) AuthenticationContext() *AuthenticationContext {
+	actx := &AuthenticationContext{ACR: r.ACR, AMR: r.AMR}
+	if r.AuthTime > 0 {
+		actx.AuthTime = time.Unix(r.AuthTime, 0)
+	}
+	return actx
+}
+
+// ParseAuthenticationContext 离线验证访问令牌并读取其中的 acr、amr 与 auth_time 声明
+func (v *JWTVerifier) ParseAuthenticationContext(token string) (*AuthenticationContext, error) {
+	if _, err := v.ParseAccessToken(token); err != nil {
+		return nil, err
+	}
+
+	// 签名已校验，可以安全读取认证声明
+	var payload struct {
+		ACR      string   `json:"acr"`
+		AMR      []string `json:"amr"`
+		AuthTime int64    `json:"auth_time"`
+	}
+	if err := jwtx.DecodePayload(token, &payload); err != nil {
+		return nil, err
+	}
+	actx := &AuthenticationContext{ACR: payload.ACR, AMR: payload.AMR}
+	if payload.AuthTime > 0 {
+		actx.AuthTime = time.Unix(payload.AuthTime, 0)
+	}
+	return actx, nil
+}
+
+// ParseAuthenticationContext 离线验证访问令牌并返回用户认证信息（acr、amr、auth_time）
+// 配合 StepUpPolicy.Check 判断是否需要升级认证
+//
+// 示例用法:
+//
+//	actx, err := client.ParseAuthenticationContext(accessToken)
+//	if err != nil {
+//	    http.Error(w, "invalid token", http.StatusUnauthorized)
+//	    return
+//	}
+//	if err := adminPolicy.Check(actx); err != nil {
+//	    goauthsdk.WriteStepUpChallenge(w, err)
+//	    return
+//	}
+func (c *Client) ParseAuthenticationContext(token string) (*AuthenticationContext, error) {
+	if c.jwtVerifier == nil {
+		return nil, ErrJWTNotConfigured
+	}
+	return c.jwtVerifier.ParseAuthenticationContext(token)
+}
+
+// StepUpPolicy 描述资源对用户认证的要求（RFC 9470）
+type StepUpPolicy struct {
+	ACRValues []string      // 可接受的认证上下文等级，为空表示不限制
+	MaxAge    time.Duration // 距上次认证的最长时间，<= 0 表示不限制
+}
+
+// RequireACR 创建步进认证策略：acr 必须为 acrValues 之一，且认证时间不早于 maxAge 之前
+//
+// 示例用法:
+//
+//	// 管理页面要求 5 分钟内完成的 MFA 认证
+//	adminPolicy := goauthsdk.RequireACR("mfa", 5*time.Minute)
+func RequireACR(acr string, maxAge time.Duration, moreACRValues ...string) *StepUpPolicy {
+	return &StepUpPolicy{
+		ACRValues: append([]string{acr}, moreACRValues...),
+		MaxAge:    maxAge,
+	}
+}
+
+// Check 检查用户认证信息是否满足策略
+// 不满足时返回 *StepUpChallenge，可直接传给 WriteStepUpChallenge；errors.Is(err, ErrInsufficientUserAuthentication) 为 true
+func (p *StepUpPolicy) Check(actx *AuthenticationContext) error {
+	if actx == nil {
+		actx = &AuthenticationContext{}
+	}
+	if len(p.ACRValues) > 0 && !slices.Contains(p.ACRValues, actx.ACR) {
+		return p.challenge("a different authentication level is required")
+	}
+	if p.MaxAge > 0 && (actx.AuthTime.IsZero() || time.Since(actx.AuthTime) > p.MaxAge) {
+		return p.challenge("more recent authentication is required")
+	}
+	return nil
+}
+
+// challenge 构建策略对应的质询
+func (p *StepUpPolicy) challenge(description string) *StepUpChallenge {
+	return &StepUpChallenge{
+		Description: description,
+		ACRValues:   p.ACRValues,
+		MaxAge:      p.MaxAge,
+		HasMaxAge:   p.MaxAge > 0,
+	}
+}
+
+// StepUpChallenge 是 RFC 9470 insufficient_user_authentication 质询
+// 资源服务器通过 WriteStepUpChallenge 写出；客户端通过 ParseStepUpChallenge 解析后调用 BuildStepUpAuthorizationURL 重新授权
+type StepUpChallenge struct {
+	Description string        // 错误描述（error_description）
+	ACRValues   []string      // 要求的认证上下文等级（acr_values），按偏好排序
+	MaxAge      time.Duration // 要求的认证时效（max_age），HasMaxAge 为 false 时无意义
+	HasMaxAge   bool          // 质询是否携带 max_age（max_age=0 表示要求立即重新认证）
+}
+
+// Error 实现 error 接口
+func (c *StepUpChallenge) Error() string {
+	if c.Description != "" {
+		return insufficientUserAuthentication + ": " + c.Description
+	}
+	return insufficientUserAuthentication
+}
+
+// Is 支持 errors.Is(err, ErrInsufficientUserAuthentication)
+func (c *StepUpChallenge) Is(target error) bool {
+	return target == ErrInsufficientUserAuthentication
+}
+
+// WriteStepUpChallenge 写出 RFC 9470 质询：401、WWW-Authenticate: Bearer error="insufficient_user_authentication"，
+// 并附带 {error, error_description} 响应体
+// err 不是 *StepUpChallenge 时按不带 acr_values/max_age 的质询处理
+func WriteStepUpChallenge(w http.ResponseWriter, err error) {
+	var challenge *StepUpChallenge
+	if !errors.As(err, &challenge) {
+		challenge = &StepUpChallenge{Description: "stronger authentication is required"}
+	}
+
+	params := map[string]string{
+		"error":             insufficientUserAuthentication,
+		"error_description": challenge.Description,
+		"acr_values":        strings.Join(challenge.ACRValues, " "),
+	}
+	if challenge.HasMaxAge {
+		params["max_age"] = strconv.FormatInt(int64(challenge.MaxAge/time.Second), 10)
+	}
+
+	w.Header().Set("WWW-Authenticate", formatWWWAuthenticate("Bearer",
+		[]string{"error", "error_description", "acr_values", "max_age"}, params))
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusUnauthorized)
+	_ = json.NewEncoder(w).Encode(oauthErrorResponse{
+		Error:            insufficientUserAuthentication,
+		ErrorDescription: challenge.Description,
+	})
+}
+
+// ParseStepUpChallenge 从 WWW-Authenticate 头部中解析 RFC 9470 质询（Bearer 或 DPoP 认证方案）
+// 头部不包含 insufficient_user_authentication 质询时返回 false
+//
+// 示例用法:
+//
+//	resp, body, err := client.DoResourceRequest(req, accessToken)
+//	if err != nil {
+//	    return err
+//	}
+//	if challenge, ok := goauthsdk.ParseStepUpChallenge(resp.Header.Get("WWW-Authenticate")); ok {
+//	    authURL, err := client.BuildStepUpAuthorizationURL(challenge, state, "openid profile")
+//	    // 将用户重定向到 authURL 完成升级认证
+//	}
+func ParseStepUpChallenge(header string) (*StepUpChallenge, bool) {
+	for _, c := range parseWWWAuthenticate(header) {
+		if !strings.EqualFold(c.scheme, "Bearer") && !strings.EqualFold(c.scheme, "DPoP") {
+			continue
+		}
+		if c.params["error"] != insufficientUserAuthentication {
+			continue
+		}
+
+		challenge := &StepUpChallenge{
+			Description: c.params["error_description"],
+			ACRValues:   strings.Fields(c.params["acr_values"]),
+		}
+		if raw, ok := c.params["max_age"]; ok {
+			if seconds, err := strconv.ParseInt(raw, 10, 64); err == nil && seconds >= 0 {
+				challenge.MaxAge = time.Duration(seconds) * time.Second
+				challenge.HasMaxAge = true
+			}
+		}
+		return challenge, true
+	}
+	return nil, false
+}
+
+// StepUpChallengeFromError 从 SDK 调用返回的 *APIError 中提取 RFC 9470 质询
+func StepUpChallengeFromError(err error) (*StepUpChallenge, bool) {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return nil, false
+	}
+	return ParseStepUpChallenge(apiErr.WWWAuthenticate)
+}
+
+// BuildStepUpAuthorizationURL 根据质询构建重新授权地址，自动附加 acr_values 与 max_age
+// 其余参数与 BuildAuthorizationURL 相同；opts 中显式设置的 acr_values/max_age 会被质询覆盖
+func (c *Client) BuildStepUpAuthorizationURL(challenge *StepUpChallenge, state, scope string, opts ...AuthorizationOption) (string, error) {
+	if challenge == nil {
+		return "", fmt.Errorf("step-up challenge is required")
+	}
+	opts = slices.Clone(opts)
+	if len(challenge.ACRValues) > 0 {
+		opts = append(opts, WithACRValues(challenge.ACRValues...))
+	}
+	if challenge.HasMaxAge {
+		opts = append(opts, WithMaxAge(challenge.MaxAge))
+	}
+	return c.BuildAuthorizationURL(state, scope, opts...)
+}
@@ -6,9 +6,6 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
-	"strings"
-
-	"github.com/3086953492/goauthsdk/internal/httpx"
 )
 
 // ExchangeToken 使用授权码交换访问令牌
@@ -18,6 +15,7 @@ import (
 // 参数:
 //   - ctx: 上下文，用于控制请求超时等
 //   - code: 从回调 URL 中获取的授权码
+//   - opts: 可选的扩展参数，例如 WithTokenRedirectURI
 //
 // 示例用法:
 //
@@ -33,13 +31,26 @@ import (
 //	// 使用访问令牌
 //	fmt.Printf("Access Token: %s\n", token.AccessToken)
 //	fmt.Printf("Expires In: %d seconds\n", token.ExpiresIn)
-func (c *Client) ExchangeToken(ctx context.Context, code string) (*TokenResponse, error) {
+func (c *Client) ExchangeToken(ctx context.Context, code string, opts ...TokenOption) (*TokenResponse, error) {
 	if code == "" {
 		return nil, fmt.Errorf("code is required")
 	}
 
+	params, err := newTokenParams("authorization_code", opts)
+	if err != nil {
+		return nil, err
+	}
+
+	redirectURI := c.cfg.RedirectURI
+	if params.redirectURI != "" {
+		if err := c.validateRedirectURI(params.redirectURI); err != nil {
+			return nil, err
+		}
+		redirectURI = params.redirectURI
+	}
+
 	// 构建并发送请求
-	req, err := buildTokenRequest(ctx, c, code)
+	req, err := buildTokenRequest(ctx, c, code, redirectURI, params.values)
 	if err != nil {
 		return nil, err
 	}
@@ -59,34 +70,32 @@ func (c *Client) ExchangeToken(ctx context.Context, code string) (*TokenResponse
 }
 
 // buildTokenRequest 构建 token 交换的 HTTP 请求
-func buildTokenRequest(ctx context.Context, c *Client, code string) (*http.Request, error) {
+// extra 为通过 TokenOption 追加的扩展参数
+func buildTokenRequest(ctx context.Context, c *Client, code, redirectURI string, extra url.Values) (*http.Request, error) {
 	// 构建请求 URL
-	tokenURL := c.cfg.BackendBaseURL + "/api/v1/oauth/token"
+	tokenURL := c.endpointURL(tokenEndpointPath)
 
 	// 构建表单参数
 	formData := url.Values{}
+	for key, values := range extra {
+		formData[key] = values
+	}
 	formData.Set("grant_type", "authorization_code")
 	formData.Set("code", code)
-	formData.Set("redirect_uri", c.cfg.RedirectURI)
+	formData.Set("redirect_uri", redirectURI)
 
-	// 创建 HTTP 请求
-	req, err := http.NewRequestWithContext(ctx, "POST", tokenURL, strings.NewReader(formData.Encode()))
+	// 创建 HTTP 请求（附加客户端认证信息）
+	req, err := newClientAuthRequest(ctx, c, tokenURL, formData)
 	if err != nil {
 		return nil, fmt.Errorf("create token request: %w", err)
 	}
 
-	// 设置 Content-Type
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-
-	// 设置 Basic Auth（client_id 和 client_secret）
-	req.SetBasicAuth(c.cfg.ClientID, c.cfg.ClientSecret)
-
 	return req, nil
 }
 
 // doTokenRequest 发送 token 请求并返回响应与响应体
 func doTokenRequest(c *Client, req *http.Request) (*http.Response, []byte, error) {
-	return httpx.Do(c.cfg.HTTPClient, req)
+	return doDPoPRequest(c, req)
 }
 
 // parseTokenResponse 解析 token 响应，检查业务成功和 HTTP 状态码
@@ -118,6 +127,7 @@ func parseTokenResponse(resp *http.Response, body []byte) (*TokenResponse, error
 // 参数:
 //   - ctx: 上下文，用于控制请求超时等
 //   - refreshToken: 之前获取的刷新令牌
+//   - opts: 可选的扩展参数，例如 WithTokenResource（将新令牌限定到部分资源）
 //
 // 示例用法:
 //
@@ -129,13 +139,18 @@ func parseTokenResponse(resp *http.Response, body []byte) (*TokenResponse, error
 //
 //	// 使用新的访问令牌
 //	fmt.Printf("New Access Token: %s\n", newToken.AccessToken.AccessToken)
-func (c *Client) RefreshToken(ctx context.Context, refreshToken string) (*TokenResponse, error) {
+func (c *Client) RefreshToken(ctx context.Context, refreshToken string, opts ...TokenOption) (*TokenResponse, error) {
 	if refreshToken == "" {
 		return nil, fmt.Errorf("refresh_token is required")
 	}
 
+	params, err := newTokenParams("refresh_token", opts)
+	if err != nil {
+		return nil, err
+	}
+
 	// 构建并发送请求
-	req, err := buildRefreshTokenRequest(ctx, c, refreshToken)
+	req, err := buildRefreshTokenRequest(ctx, c, refreshToken, params.values)
 	if err != nil {
 		return nil, err
 	}
@@ -155,27 +170,25 @@ func (c *Client) RefreshToken(ctx context.Context, refreshToken string) (*TokenR
 }
 
 // buildRefreshTokenRequest 构建刷新令牌的 HTTP 请求
-func buildRefreshTokenRequest(ctx context.Context, c *Client, refreshToken string) (*http.Request, error) {
+// extra 为通过 TokenOption 追加的扩展参数
+func buildRefreshTokenRequest(ctx context.Context, c *Client, refreshToken string, extra url.Values) (*http.Request, error) {
 	// 构建请求 URL
-	tokenURL := c.cfg.BackendBaseURL + "/api/v1/oauth/token"
+	tokenURL := c.endpointURL(tokenEndpointPath)
 
 	// 构建表单参数
 	formData := url.Values{}
+	for key, values := range extra {
+		formData[key] = values
+	}
 	formData.Set("grant_type", "refresh_token")
 	formData.Set("refresh_token", refreshToken)
 
-	// 创建 HTTP 请求
-	req, err := http.NewRequestWithContext(ctx, "POST", tokenURL, strings.NewReader(formData.Encode()))
+	// 创建 HTTP 请求（附加客户端认证信息）
+	req, err := newClientAuthRequest(ctx, c, tokenURL, formData)
 	if err != nil {
 		return nil, fmt.Errorf("create refresh token request: %w", err)
 	}
 
-	// 设置 Content-Type
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-
-	// 设置 Basic Auth（client_id 和 client_secret）
-	req.SetBasicAuth(c.cfg.ClientID, c.cfg.ClientSecret)
-
 	return req, nil
 }
 
@@ -185,6 +198,7 @@ func buildRefreshTokenRequest(ctx context.Context, c *Client, refreshToken strin
 // 参数:
 //   - ctx: 上下文，用于控制请求超时等
 //   - scope: 请求的权限范围；为空时服务端返回的 scope 也为空，不会自动赋默认值
+//   - opts: 可选的扩展参数，例如 WithTokenResource（申请面向指定资源的令牌）
 //
 // 注意事项:
 //   - 该模式下 JWT 的 sub 固定为 "client:<client_id>"
@@ -201,9 +215,14 @@ func buildRefreshTokenRequest(ctx context.Context, c *Client, refreshToken strin
 //	// 使用访问令牌调用业务 API
 //	fmt.Printf("Access Token: %s\n", token.AccessToken)
 //	fmt.Printf("Expires In: %d seconds\n", token.ExpiresIn)
-func (c *Client) ClientCredentialsToken(ctx context.Context, scope string) (*ClientCredentialsTokenResponse, error) {
+func (c *Client) ClientCredentialsToken(ctx context.Context, scope string, opts ...TokenOption) (*ClientCredentialsTokenResponse, error) {
+	params, err := newTokenParams("client_credentials", opts)
+	if err != nil {
+		return nil, err
+	}
+
 	// 构建并发送请求
-	req, err := buildClientCredentialsTokenRequest(ctx, c, scope)
+	req, err := buildClientCredentialsTokenRequest(ctx, c, scope, params.values)
 	if err != nil {
 		return nil, err
 	}
@@ -223,29 +242,27 @@ func (c *Client) ClientCredentialsToken(ctx context.Context, scope string) (*Cli
 }
 
 // buildClientCredentialsTokenRequest 构建客户端凭证模式的 HTTP 请求
-func buildClientCredentialsTokenRequest(ctx context.Context, c *Client, scope string) (*http.Request, error) {
+// extra 为通过 TokenOption 追加的扩展参数
+func buildClientCredentialsTokenRequest(ctx context.Context, c *Client, scope string, extra url.Values) (*http.Request, error) {
 	// 构建请求 URL
-	tokenURL := c.cfg.BackendBaseURL + "/api/v1/oauth/token"
+	tokenURL := c.endpointURL(tokenEndpointPath)
 
 	// 构建表单参数
 	formData := url.Values{}
+	for key, values := range extra {
+		formData[key] = values
+	}
 	formData.Set("grant_type", "client_credentials")
 	if scope != "" {
 		formData.Set("scope", scope)
 	}
 
-	// 创建 HTTP 请求
-	req, err := http.NewRequestWithContext(ctx, "POST", tokenURL, strings.NewReader(formData.Encode()))
+	// 创建 HTTP 请求（附加客户端认证信息）
+	req, err := newClientAuthRequest(ctx, c, tokenURL, formData)
 	if err != nil {
 		return nil, fmt.Errorf("create client credentials token request: %w", err)
 	}
 
-	// 设置 Content-Type
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-
-	// 设置 Basic Auth（client_id 和 client_secret）
-	req.SetBasicAuth(c.cfg.ClientID, c.cfg.ClientSecret)
-
 	return req, nil
 }
 
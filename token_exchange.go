@@ -0,0 +1,224 @@
+package goauthsdk
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	// tokenExchangeGrantType 是令牌交换的 grant_type（RFC 8693 2.1）
+	tokenExchangeGrantType = "urn:ietf:params:oauth:grant-type:token-exchange"
+
+	// tokenExchangeCacheSkew 是缓存令牌提前失效的时间窗口，避免返回即将过期的令牌
+	tokenExchangeCacheSkew = 30 * time.Second
+
+	// sweepInterval 是内存缓存清理过期条目的最小间隔，避免每次写入都遍历全部条目
+	sweepInterval = time.Minute
+)
+
+// ExchangeTokenFor 使用令牌交换（RFC 8693）获取面向下游服务的令牌
+// 典型场景：API 网关将收到的用户访问令牌交换为指定 audience 的下游令牌
+//
+// 交换结果按 subject_token、actor_token、audience、resource、requested_token_type、scope 缓存，
+// 在令牌过期前（提前 30 秒）重复调用直接返回缓存结果，不再请求服务端
+//
+// 参数:
+//   - ctx: 上下文，用于控制请求超时等
+//   - req: 令牌交换请求参数
+//
+// 示例用法:
+//
+//	resp, err := client.ExchangeTokenFor(ctx, goauthsdk.TokenExchangeRequest{
+//	    SubjectToken: incomingAccessToken,
+//	    Audience:     []string{"orders-service"},
+//	    Scope:        "orders:read",
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	downstreamReq.Header.Set("Authorization", "Bearer "+resp.AccessToken)
+func (c *Client) ExchangeTokenFor(ctx context.Context, req TokenExchangeRequest) (*TokenExchangeResponse, error) {
+	if req.SubjectToken == "" {
+		return nil, fmt.Errorf("subject_token is required")
+	}
+	if req.SubjectTokenType == "" {
+		req.SubjectTokenType = TokenTypeAccessToken
+	}
+	if req.ActorToken != "" && req.ActorTokenType == "" {
+		req.ActorTokenType = TokenTypeAccessToken
+	}
+
+	key := tokenExchangeCacheKey(req)
+	if cached, ok := c.exchangeCache.get(key); ok {
+		return cached, nil
+	}
+
+	// 构建并发送请求
+	httpReq, err := buildTokenExchangeRequest(ctx, c, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, body, err := doTokenRequest(c, httpReq)
+	if err != nil {
+		return nil, err
+	}
+
+	// 解析响应
+	exchanged, err := parseTokenExchangeResponse(resp, body)
+	if err != nil {
+		return nil, err
+	}
+
+	c.exchangeCache.put(key, exchanged)
+	return exchanged, nil
+}
+
+// buildTokenExchangeRequest 构建令牌交换的 HTTP 请求
+func buildTokenExchangeRequest(ctx context.Context, c *Client, req TokenExchangeRequest) (*http.Request, error) {
+	// 构建请求 URL
+	tokenURL := c.endpointURL(tokenEndpointPath)
+
+	// 构建表单参数
+	formData := url.Values{}
+	formData.Set("grant_type", tokenExchangeGrantType)
+	formData.Set("subject_token", req.SubjectToken)
+	formData.Set("subject_token_type", req.SubjectTokenType)
+	if req.ActorToken != "" {
+		formData.Set("actor_token", req.ActorToken)
+		formData.Set("actor_token_type", req.ActorTokenType)
+	}
+	for _, audience := range req.Audience {
+		formData.Add("audience", audience)
+	}
+	for _, resource := range req.Resource {
+		formData.Add("resource", resource)
+	}
+	if req.RequestedTokenType != "" {
+		formData.Set("requested_token_type", req.RequestedTokenType)
+	}
+	if req.Scope != "" {
+		formData.Set("scope", req.Scope)
+	}
+
+	// 创建 HTTP 请求（附加客户端认证信息）
+	httpReq, err := newClientAuthRequest(ctx, c, tokenURL, formData)
+	if err != nil {
+		return nil, fmt.Errorf("create token exchange request: %w", err)
+	}
+
+	return httpReq, nil
+}
+
+// parseTokenExchangeResponse 解析令牌交换响应
+// 响应格式：{ "code": 0, "message": "...", "data": { "access_token": "...", "issued_token_type": "...", ... } }
+func parseTokenExchangeResponse(resp *http.Response, body []byte) (*TokenExchangeResponse, error) {
+	// 非 2xx：统一走 decodeAPIError
+	if resp.StatusCode != http.StatusOK {
+		return nil, decodeAPIError(resp, body)
+	}
+
+	// 解析响应
+	var apiResp apiCodeResponse[TokenExchangeResponse]
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return nil, fmt.Errorf("parse token exchange response: %w", err)
+	}
+
+	// 检查业务是否成功（code == 0 表示成功）
+	if apiResp.Code != 0 {
+		return nil, newBusinessError(resp.StatusCode, apiResp.Code, apiResp.Message)
+	}
+
+	return &apiResp.Data, nil
+}
+
+// tokenExchangeCacheKey 根据影响交换结果的全部参数计算缓存 key
+// 参数以 JSON 数组编码（Audience、Resource 排序后编码为子数组），保证不同参数组合不会得到相同的 key；
+// 使用 SHA-256 摘要，避免在内存中以明文 key 形式保留令牌
+func tokenExchangeCacheKey(req TokenExchangeRequest) string {
+	audience := slices.Clone(req.Audience)
+	slices.Sort(audience)
+	resource := slices.Clone(req.Resource)
+	slices.Sort(resource)
+
+	// 字段均为字符串与字符串切片，编码不会失败
+	encoded, _ := json.Marshal([]any{
+		req.SubjectToken,
+		req.SubjectTokenType,
+		req.ActorToken,
+		req.ActorTokenType,
+		audience,
+		resource,
+		req.RequestedTokenType,
+		req.Scope,
+	})
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:])
+}
+
+// tokenExchangeCache 是令牌交换结果的内存缓存
+type tokenExchangeCache struct {
+	mu        sync.Mutex
+	entries   map[string]tokenExchangeEntry
+	lastSweep time.Time
+}
+
+// tokenExchangeEntry 是缓存条目
+type tokenExchangeEntry struct {
+	resp      TokenExchangeResponse
+	expiresAt time.Time
+}
+
+// newTokenExchangeCache 创建空缓存
+func newTokenExchangeCache() *tokenExchangeCache {
+	return &tokenExchangeCache{entries: make(map[string]tokenExchangeEntry)}
+}
+
+// get 返回未过期的缓存结果副本
+func (t *tokenExchangeCache) get(key string) (*TokenExchangeResponse, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	entry, ok := t.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !time.Now().Before(entry.expiresAt) {
+		delete(t.entries, key)
+		return nil, false
+	}
+	resp := entry.resp
+	return &resp, true
+}
+
+// put 缓存交换结果；未返回 expires_in 或有效期过短的令牌不缓存
+// 写入时按 sweepInterval 惰性清理已过期条目，避免缓存无限增长
+func (t *tokenExchangeCache) put(key string, resp *TokenExchangeResponse) {
+	ttl := time.Duration(resp.ExpiresIn)*time.Second - tokenExchangeCacheSkew
+	if ttl <= 0 {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	t.entries[key] = tokenExchangeEntry{resp: *resp, expiresAt: now.Add(ttl)}
+
+	if now.Sub(t.lastSweep) >= sweepInterval {
+		for k, entry := range t.entries {
+			if !now.Before(entry.expiresAt) {
+				delete(t.entries, k)
+			}
+		}
+		t.lastSweep = now
+	}
+}
@@ -0,0 +1,96 @@
+package goauthsdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestTokenExchangeCacheKeyDistinguishesParameters(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if err := r.ParseForm(); err != nil {
+			t.Error(err)
+		}
+		// 签发的令牌反映收到的 audience 与 resource，便于区分缓存命中的是哪个请求
+		token := strings.Join(r.PostForm["audience"], "|") + "#" + strings.Join(r.PostForm["resource"], "|")
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"code":    0,
+			"message": "success",
+			"data": map[string]any{
+				"access_token":      token,
+				"issued_token_type": TokenTypeAccessToken,
+				"token_type":        "Bearer",
+				"expires_in":        3600,
+			},
+		})
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(srv.URL, srv.URL, "client-1", "client-secret", "https://app.example.com/callback")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 旧实现以空格拼接 audience/resource、以 \x00 拼接字段，以下每组请求会得到相同的缓存 key
+	tests := []struct {
+		name string
+		a, b TokenExchangeRequest
+	}{
+		{
+			"audience containing space",
+			TokenExchangeRequest{SubjectToken: "subject-1", Audience: []string{"a b"}},
+			TokenExchangeRequest{SubjectToken: "subject-1", Audience: []string{"a", "b"}},
+		},
+		{
+			"resource containing space",
+			TokenExchangeRequest{SubjectToken: "subject-2", Resource: []string{"https://api.example.com/a https://api.example.com/b"}},
+			TokenExchangeRequest{SubjectToken: "subject-2", Resource: []string{"https://api.example.com/a", "https://api.example.com/b"}},
+		},
+		{
+			"separator moved between fields",
+			TokenExchangeRequest{SubjectToken: "subject-3", Audience: []string{"a\x00"}, Resource: []string{"b"}},
+			TokenExchangeRequest{SubjectToken: "subject-3", Audience: []string{"a"}, Resource: []string{"\x00b"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tokenExchangeCacheKey(tt.a) == tokenExchangeCacheKey(tt.b) {
+				t.Fatal("cache keys collide")
+			}
+
+			before := calls.Load()
+			first, err := client.ExchangeTokenFor(context.Background(), tt.a)
+			if err != nil {
+				t.Fatal(err)
+			}
+			second, err := client.ExchangeTokenFor(context.Background(), tt.b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n := calls.Load() - before; n != 2 {
+				t.Errorf("token endpoint called %d times, want 2", n)
+			}
+			if first.AccessToken == second.AccessToken {
+				t.Errorf("second request returned the first request's cached token %q", first.AccessToken)
+			}
+		})
+	}
+
+	// audience 顺序不同但集合相同时命中缓存
+	before := calls.Load()
+	for _, audience := range [][]string{{"x", "y"}, {"y", "x"}} {
+		if _, err := client.ExchangeTokenFor(context.Background(), TokenExchangeRequest{SubjectToken: "subject-4", Audience: audience}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := calls.Load() - before; n != 1 {
+		t.Errorf("token endpoint called %d times for reordered audience, want 1", n)
+	}
+}
@@ -0,0 +1,92 @@
+package goauthsdk
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// TokenOption 用于设置令牌请求的扩展参数
+type TokenOption func(*tokenParams)
+
+// tokenParams 是令牌请求的扩展参数集合
+type tokenParams struct {
+	redirectURI string
+	values      url.Values
+	err         error
+}
+
+// WithTokenRedirectURI 设置授权码交换时的 redirect_uri
+// 授权请求通过 WithRedirectURI 覆盖了 redirect_uri 时，交换令牌必须传入相同地址；仅对 ExchangeToken 生效
+func WithTokenRedirectURI(redirectURI string) TokenOption {
+	return func(p *tokenParams) {
+		p.redirectURI = redirectURI
+	}
+}
+
+// WithTokenResource 设置 resource 参数（RFC 8707），申请只能用于指定资源服务器的令牌
+// 可用于 ExchangeToken、RefreshToken（在授权范围内收窄令牌的 audience）与 ClientCredentialsToken；
+// 每个资源必须是不含 fragment 的绝对 URI
+func WithTokenResource(resources ...string) TokenOption {
+	return func(p *tokenParams) {
+		for _, resource := range resources {
+			if err := validateResourceIndicator(resource); err != nil {
+				p.err = err
+				return
+			}
+			p.values.Add("resource", resource)
+		}
+	}
+}
+
+// WithTokenAuthorizationDetails 设置令牌请求的 authorization_details 参数（RAR，RFC 9396 6）
+// 用于在已授权范围内申请部分授权详情；元素要求与 WithAuthorizationDetails 相同
+func WithTokenAuthorizationDetails(details ...any) TokenOption {
+	return func(p *tokenParams) {
+		encoded, err := encodeAuthorizationDetails(details)
+		if err != nil {
+			p.err = err
+			return
+		}
+		p.values.Set("authorization_details", encoded)
+	}
+}
+
+// WithCodeVerifier 设置授权码交换的 code_verifier 参数（RFC 7636 4.5），仅用于 ExchangeToken；
+// 传给 RefreshToken、ClientCredentialsToken 时返回错误
+func WithCodeVerifier(verifier string) TokenOption {
+	return func(p *tokenParams) {
+		if verifier == "" {
+			p.err = fmt.Errorf("code_verifier is required")
+			return
+		}
+		p.values.Set("code_verifier", verifier)
+	}
+}
+
+// validateResourceIndicator 校验资源标识：必须是绝对 URI 且不含 fragment（RFC 8707 2）
+func validateResourceIndicator(resource string) error {
+	u, err := url.Parse(resource)
+	if err != nil || !u.IsAbs() {
+		return fmt.Errorf("resource must be an absolute uri: %s", resource)
+	}
+	if u.Fragment != "" || u.RawFragment != "" {
+		return fmt.Errorf("resource must not contain a fragment: %s", resource)
+	}
+	return nil
+}
+
+// newTokenParams 应用令牌请求选项
+// grantType 不是 authorization_code 时拒绝 code_verifier，避免其被附加到刷新、客户端凭证等请求中
+func newTokenParams(grantType string, opts []TokenOption) (*tokenParams, error) {
+	params := &tokenParams{values: url.Values{}}
+	for _, opt := range opts {
+		opt(params)
+	}
+	if params.err != nil {
+		return nil, params.err
+	}
+	if grantType != "authorization_code" && params.values.Has("code_verifier") {
+		return nil, fmt.Errorf("code_verifier is not supported for grant_type %s", grantType)
+	}
+	return params, nil
+}
@@ -0,0 +1,153 @@
+package goauthsdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// tokenRefreshSkew 是访问令牌到期前提前刷新的时间窗口，避免令牌在请求途中过期
+const tokenRefreshSkew = time.Minute
+
+// ErrTokenExpired 表示持久化的令牌已过期且无法刷新（无刷新令牌或刷新令牌已过期），需要重新授权
+var ErrTokenExpired = errors.New("stored token expired and cannot be refreshed")
+
+// TokenRefresher 基于 TokenStore 维护一份可自动刷新的令牌
+// 适用于长时间运行的 worker 或 CLI：进程重启后从存储中恢复令牌，访问令牌临近过期时自动调用 RefreshToken，
+// 并保证服务端轮换后的新刷新令牌先持久化成功、再返回给调用方使用
+//
+// TokenRefresher 并发安全；同一进程内对同一 key 应共享一个实例
+type TokenRefresher struct {
+	client *Client
+	store  TokenStore
+	key    string
+	now    func() time.Time
+
+	mu sync.Mutex
+}
+
+// NewTokenRefresher 创建令牌刷新器
+//
+// 参数:
+//   - client: 用于刷新令牌的 goauth 客户端
+//   - store: 令牌存储
+//   - key: 令牌在存储中的 key
+//
+// 示例用法:
+//
+//	store, _ := goauthsdk.NewFileTokenStore("/var/lib/worker/tokens.json")
+//	refresher, err := goauthsdk.NewTokenRefresher(client, store, "default")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	// 首次授权后保存令牌
+//	token, _ := client.ExchangeToken(ctx, code)
+//	if _, err := refresher.Save(ctx, token); err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	// 之后每次使用前获取（必要时自动刷新）
+//	accessToken, err := refresher.AccessToken(ctx)
+func NewTokenRefresher(client *Client, store TokenStore, key string) (*TokenRefresher, error) {
+	if client == nil {
+		return nil, fmt.Errorf("client is required")
+	}
+	if store == nil {
+		return nil, fmt.Errorf("token store is required")
+	}
+	if key == "" {
+		return nil, fmt.Errorf("key is required")
+	}
+	return &TokenRefresher{client: client, store: store, key: key, now: time.Now}, nil
+}
+
+// Save 将 TokenResponse 持久化到存储，通常在 ExchangeToken 成功后调用
+func (t *TokenRefresher) Save(ctx context.Context, resp *TokenResponse) (*StoredToken, error) {
+	token, err := NewStoredToken(resp, t.now())
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err := t.store.Save(ctx, t.key, token); err != nil {
+		return nil, fmt.Errorf("save token: %w", err)
+	}
+	return token, nil
+}
+
+// Token 返回当前有效的令牌，访问令牌临近过期时自动刷新
+//
+// 返回值:
+//   - *StoredToken: 有效令牌
+//   - error: 存储中无令牌返回 ErrTokenNotFound；无法刷新返回 ErrTokenExpired；
+//     刷新请求失败可通过 errors.As 获取 *APIError；刷新成功但持久化失败时返回错误且不返回新令牌
+func (t *TokenRefresher) Token(ctx context.Context) (*StoredToken, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	token, err := t.store.Load(ctx, t.key)
+	if err != nil {
+		if errors.Is(err, ErrTokenNotFound) {
+			return nil, ErrTokenNotFound
+		}
+		return nil, fmt.Errorf("load token: %w", err)
+	}
+
+	now := t.now()
+	if now.Add(tokenRefreshSkew).Unix() < token.AccessTokenExpiresAt {
+		return token, nil
+	}
+
+	if token.RefreshToken == "" {
+		return nil, ErrTokenExpired
+	}
+	if token.RefreshTokenExpiresAt != 0 && now.Unix() >= token.RefreshTokenExpiresAt {
+		return nil, ErrTokenExpired
+	}
+
+	resp, err := t.client.RefreshToken(ctx, token.RefreshToken)
+	if err != nil {
+		return nil, fmt.Errorf("refresh token: %w", err)
+	}
+
+	refreshed, err := NewStoredToken(resp, now)
+	if err != nil {
+		return nil, fmt.Errorf("refresh token: %w", err)
+	}
+	// 服务端未轮换刷新令牌时沿用旧值
+	if refreshed.RefreshToken == "" {
+		refreshed.RefreshToken = token.RefreshToken
+		refreshed.RefreshTokenExpiresAt = token.RefreshTokenExpiresAt
+	}
+
+	// 先持久化再返回：若持久化失败，调用方拿不到新令牌，避免轮换后的刷新令牌丢失
+	if err := t.store.Save(ctx, t.key, refreshed); err != nil {
+		return nil, fmt.Errorf("save refreshed token: %w", err)
+	}
+	return refreshed, nil
+}
+
+// AccessToken 返回当前有效的访问令牌字符串，必要时自动刷新
+func (t *TokenRefresher) AccessToken(ctx context.Context) (string, error) {
+	token, err := t.Token(ctx)
+	if err != nil {
+		return "", err
+	}
+	return token.AccessToken, nil
+}
+
+// Delete 从存储中删除令牌，通常在退出登录或撤销令牌后调用
+func (t *TokenRefresher) Delete(ctx context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err := t.store.Delete(ctx, t.key); err != nil {
+		return fmt.Errorf("delete token: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package goauthsdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrTokenNotFound 表示 TokenStore 中不存在指定 key 的令牌
+var ErrTokenNotFound = errors.New("token not found")
+
+// TokenStore 是令牌持久化接口
+// key 由调用方定义（例如用户 ID、profile 名称），用于区分多份令牌
+//
+// 实现约定:
+//   - Load 在 key 不存在时返回 ErrTokenNotFound
+//   - Save 覆盖已有令牌，返回 nil 即表示已持久化；token 为 nil 时返回错误
+//   - Delete 对不存在的 key 不返回错误
+type TokenStore interface {
+	Load(ctx context.Context, key string) (*StoredToken, error)
+	Save(ctx context.Context, key string, token *StoredToken) error
+	Delete(ctx context.Context, key string) error
+}
+
+// NewStoredToken 将 TokenResponse 转换为 StoredToken
+// now 为令牌签发的参考时间，通常传 time.Now()；resp 为 nil 时返回错误
+func NewStoredToken(resp *TokenResponse, now time.Time) (*StoredToken, error) {
+	if resp == nil {
+		return nil, fmt.Errorf("token response is required")
+	}
+
+	token := &StoredToken{
+		AccessToken:          resp.AccessToken.AccessToken,
+		RefreshToken:         resp.RefreshToken.RefreshToken,
+		TokenType:            resp.TokenType,
+		Scope:                resp.Scope,
+		AccessTokenExpiresAt: now.Add(time.Duration(resp.AccessToken.ExpiresIn) * time.Second).Unix(),
+	}
+	if resp.RefreshToken.ExpiresIn > 0 {
+		token.RefreshTokenExpiresAt = now.Add(time.Duration(resp.RefreshToken.ExpiresIn) * time.Second).Unix()
+	}
+	return token, nil
+}
+
+// MemoryTokenStore 是基于内存的 TokenStore 实现
+// 适用于测试或无需跨进程持久化的场景
+type MemoryTokenStore struct {
+	mu     sync.RWMutex
+	tokens map[string]StoredToken
+}
+
+// NewMemoryTokenStore 创建一个空的内存令牌存储
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{tokens: make(map[string]StoredToken)}
+}
+
+// Load 读取令牌，不存在时返回 ErrTokenNotFound
+func (m *MemoryTokenStore) Load(ctx context.Context, key string) (*StoredToken, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	token, ok := m.tokens[key]
+	if !ok {
+		return nil, ErrTokenNotFound
+	}
+	return &token, nil
+}
+
+// Save 保存令牌（保存副本，后续修改入参不影响已存储数据）
+func (m *MemoryTokenStore) Save(ctx context.Context, key string, token *StoredToken) error {
+	if token == nil {
+		return fmt.Errorf("token is required")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.tokens[key] = *token
+	return nil
+}
+
+// Delete 删除令牌
+func (m *MemoryTokenStore) Delete(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.tokens, key)
+	return nil
+}
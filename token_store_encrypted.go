@@ -0,0 +1,107 @@
+package goauthsdk
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/3086953492/goauthsdk/internal/cryptox"
+)
+
+// EncryptedTokenStore 是为任意 TokenStore 增加加密能力的包装器
+// 访问令牌与刷新令牌使用 AES-GCM 加密后再交给底层存储，其余字段（过期时间等）保持明文便于排查
+type EncryptedTokenStore struct {
+	inner TokenStore
+	keys  *cryptox.KeyRing
+}
+
+// NewEncryptedTokenStore 创建加密令牌存储
+//
+// 参数:
+//   - inner: 底层存储（例如 FileTokenStore）
+//   - keys: 加密密钥（每个 16/24/32 字节）；第一个用于加密，全部用于解密，支持密钥轮换
+func NewEncryptedTokenStore(inner TokenStore, keys ...[]byte) (*EncryptedTokenStore, error) {
+	if inner == nil {
+		return nil, fmt.Errorf("inner token store is required")
+	}
+	keyRing, err := cryptox.NewKeyRing(keys...)
+	if err != nil {
+		return nil, fmt.Errorf("create token key ring: %w", err)
+	}
+	return &EncryptedTokenStore{inner: inner, keys: keyRing}, nil
+}
+
+// Load 从底层存储读取并解密令牌
+func (e *EncryptedTokenStore) Load(ctx context.Context, key string) (*StoredToken, error) {
+	token, err := e.inner.Load(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	accessToken, err := e.open(token.AccessToken, key, "access_token")
+	if err != nil {
+		return nil, err
+	}
+	refreshToken, err := e.open(token.RefreshToken, key, "refresh_token")
+	if err != nil {
+		return nil, err
+	}
+
+	token.AccessToken = accessToken
+	token.RefreshToken = refreshToken
+	return token, nil
+}
+
+// Save 加密令牌后保存到底层存储
+func (e *EncryptedTokenStore) Save(ctx context.Context, key string, token *StoredToken) error {
+	if token == nil {
+		return fmt.Errorf("token is required")
+	}
+
+	accessToken, err := e.seal(token.AccessToken, key, "access_token")
+	if err != nil {
+		return err
+	}
+	refreshToken, err := e.seal(token.RefreshToken, key, "refresh_token")
+	if err != nil {
+		return err
+	}
+
+	encrypted := *token
+	encrypted.AccessToken = accessToken
+	encrypted.RefreshToken = refreshToken
+	return e.inner.Save(ctx, key, &encrypted)
+}
+
+// Delete 从底层存储删除令牌
+func (e *EncryptedTokenStore) Delete(ctx context.Context, key string) error {
+	return e.inner.Delete(ctx, key)
+}
+
+// seal 加密单个字段；key 与字段名作为附加认证数据，防止密文在 key/字段之间被挪用
+func (e *EncryptedTokenStore) seal(value, key, field string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	ciphertext, err := e.keys.Seal([]byte(value), []byte(key+"\x00"+field))
+	if err != nil {
+		return "", fmt.Errorf("encrypt %s: %w", field, err)
+	}
+	return base64.RawURLEncoding.EncodeToString(ciphertext), nil
+}
+
+// open 解密单个字段
+func (e *EncryptedTokenStore) open(value, key, field string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	ciphertext, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return "", fmt.Errorf("decode %s: %w", field, err)
+	}
+	plaintext, err := e.keys.Open(ciphertext, []byte(key+"\x00"+field))
+	if err != nil {
+		return "", fmt.Errorf("decrypt %s: %w", field, err)
+	}
+	return string(plaintext), nil
+}
@@ -0,0 +1,139 @@
+package goauthsdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// FileTokenStore 是基于 JSON 文件的 TokenStore 实现
+// 所有 key 的令牌保存在同一个文件中，文件权限为 0600；
+// 写入时先写临时文件再原子重命名，进程中途崩溃也不会留下半写的文件
+type FileTokenStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileTokenStore 创建文件令牌存储
+// path 所在目录不存在时会在首次 Save 时以 0700 权限创建
+func NewFileTokenStore(path string) (*FileTokenStore, error) {
+	if path == "" {
+		return nil, fmt.Errorf("path is required")
+	}
+	return &FileTokenStore{path: path}, nil
+}
+
+// Load 读取令牌，文件或 key 不存在时返回 ErrTokenNotFound
+func (f *FileTokenStore) Load(ctx context.Context, key string) (*StoredToken, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	tokens, err := f.readAll()
+	if err != nil {
+		return nil, err
+	}
+	token, ok := tokens[key]
+	if !ok {
+		return nil, ErrTokenNotFound
+	}
+	return token, nil
+}
+
+// Save 保存令牌并原子写回文件
+func (f *FileTokenStore) Save(ctx context.Context, key string, token *StoredToken) error {
+	if token == nil {
+		return fmt.Errorf("token is required")
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	tokens, err := f.readAll()
+	if err != nil {
+		return err
+	}
+	tokens[key] = token
+	return f.writeAll(tokens)
+}
+
+// Delete 删除令牌并原子写回文件
+func (f *FileTokenStore) Delete(ctx context.Context, key string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	tokens, err := f.readAll()
+	if err != nil {
+		return err
+	}
+	if _, ok := tokens[key]; !ok {
+		return nil
+	}
+	delete(tokens, key)
+	return f.writeAll(tokens)
+}
+
+// readAll 读取文件中的全部令牌，文件不存在时返回空 map
+func (f *FileTokenStore) readAll() (map[string]*StoredToken, error) {
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return make(map[string]*StoredToken), nil
+		}
+		return nil, fmt.Errorf("read token file: %w", err)
+	}
+
+	tokens := make(map[string]*StoredToken)
+	if len(data) == 0 {
+		return tokens, nil
+	}
+	if err := json.Unmarshal(data, &tokens); err != nil {
+		return nil, fmt.Errorf("parse token file: %w", err)
+	}
+	return tokens, nil
+}
+
+// writeAll 将全部令牌写入临时文件后重命名覆盖目标文件
+func (f *FileTokenStore) writeAll(tokens map[string]*StoredToken) error {
+	data, err := json.MarshalIndent(tokens, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode token file: %w", err)
+	}
+
+	dir := filepath.Dir(f.path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("create token directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(f.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create temp token file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	// 失败时清理临时文件；重命名成功后 Remove 会返回不存在错误，忽略即可
+	defer os.Remove(tmpPath)
+
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return fmt.Errorf("chmod temp token file: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write temp token file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("sync temp token file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close temp token file: %w", err)
+	}
+	if err := os.Rename(tmpPath, f.path); err != nil {
+		return fmt.Errorf("rename token file: %w", err)
+	}
+	return nil
+}
@@ -19,6 +19,8 @@ type TokenResponse struct {
 	RefreshToken RefreshTokenInfo `json:"refresh_token"` // 刷新令牌信息
 	TokenType    string           `json:"token_type"`    // 令牌类型，通常为 "Bearer"
 	Scope        string           `json:"scope"`         // 授权范围
+
+	AuthorizationDetails AuthorizationDetails `json:"authorization_details,omitempty"` // 授权详情（RFC 9396 7），未使用 RAR 时为空
 }
 
 // ClientCredentialsTokenResponse 是客户端凭证模式（client_credentials）的访问令牌响应
@@ -28,4 +30,47 @@ type ClientCredentialsTokenResponse struct {
 	ExpiresIn   int    `json:"expires_in"`   // 过期时间（秒）
 	TokenType   string `json:"token_type"`   // 令牌类型，通常为 "Bearer"
 	Scope       string `json:"scope"`        // 授权范围；为空时返回空字符串
+
+	AuthorizationDetails AuthorizationDetails `json:"authorization_details,omitempty"` // 授权详情（RFC 9396 7），未使用 RAR 时为空
+}
+
+// StoredToken 是持久化保存的令牌
+// 由 TokenResponse 转换而来，将相对过期时间（expires_in）换算为绝对时间，便于跨进程重启后判断是否过期
+type StoredToken struct {
+	AccessToken           string `json:"access_token"`                       // 访问令牌
+	RefreshToken          string `json:"refresh_token,omitempty"`            // 刷新令牌
+	TokenType             string `json:"token_type,omitempty"`               // 令牌类型，通常为 "Bearer"
+	Scope                 string `json:"scope,omitempty"`                    // 授权范围
+	AccessTokenExpiresAt  int64  `json:"access_token_expires_at"`            // 访问令牌过期时间（Unix 时间戳，秒）
+	RefreshTokenExpiresAt int64  `json:"refresh_token_expires_at,omitempty"` // 刷新令牌过期时间（Unix 时间戳，秒），0 表示未知
+}
+
+// 令牌类型标识（RFC 8693 3），用于 TokenExchangeRequest 的 *TokenType 字段
+const (
+	TokenTypeAccessToken  = "urn:ietf:params:oauth:token-type:access_token"  // 访问令牌
+	TokenTypeRefreshToken = "urn:ietf:params:oauth:token-type:refresh_token" // 刷新令牌
+	TokenTypeIDToken      = "urn:ietf:params:oauth:token-type:id_token"      // OIDC ID Token
+	TokenTypeJWT          = "urn:ietf:params:oauth:token-type:jwt"           // 任意 JWT
+)
+
+// TokenExchangeRequest 令牌交换请求参数（RFC 8693 2.1）
+type TokenExchangeRequest struct {
+	SubjectToken       string   // 必填，被交换的令牌（例如网关收到的用户访问令牌）
+	SubjectTokenType   string   // SubjectToken 的类型，为空时默认 TokenTypeAccessToken
+	ActorToken         string   // 可选，代表调用方（委托场景）的令牌
+	ActorTokenType     string   // ActorToken 的类型，设置 ActorToken 时为空默认 TokenTypeAccessToken
+	Audience           []string // 可选，目标服务的逻辑名称
+	Resource           []string // 可选，目标服务的绝对 URI
+	RequestedTokenType string   // 可选，期望签发的令牌类型
+	Scope              string   // 可选，期望的权限范围，多个 scope 用空格分隔
+}
+
+// TokenExchangeResponse 令牌交换响应（RFC 8693 2.2.1）
+type TokenExchangeResponse struct {
+	AccessToken     string `json:"access_token"`            // 签发的令牌（字段名固定为 access_token，实际类型见 IssuedTokenType）
+	IssuedTokenType string `json:"issued_token_type"`       // 签发的令牌类型
+	TokenType       string `json:"token_type"`              // 令牌使用方式，通常为 "Bearer"
+	ExpiresIn       int    `json:"expires_in,omitempty"`    // 过期时间（秒）
+	Scope           string `json:"scope,omitempty"`         // 授权范围
+	RefreshToken    string `json:"refresh_token,omitempty"` // 刷新令牌（通常不返回）
 }
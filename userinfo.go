@@ -5,8 +5,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-
-	"github.com/3086953492/goauthsdk/internal/httpx"
 )
 
 // UserInfo 获取当前访问令牌对应的用户信息
@@ -56,7 +54,7 @@ func (c *Client) UserInfo(ctx context.Context, accessToken string) (*UserInfo, e
 // buildUserInfoRequest 构建获取用户信息的 HTTP 请求
 func buildUserInfoRequest(ctx context.Context, c *Client, accessToken string) (*http.Request, error) {
 	// 构建请求 URL
-	userInfoURL := c.cfg.BackendBaseURL + "/api/v1/oauth/userinfo"
+	userInfoURL := c.endpointURL(userInfoEndpointPath)
 
 	// 创建 HTTP 请求
 	req, err := http.NewRequestWithContext(ctx, "GET", userInfoURL, nil)
@@ -64,15 +62,15 @@ func buildUserInfoRequest(ctx context.Context, c *Client, accessToken string) (*
 		return nil, fmt.Errorf("create userinfo request: %w", err)
 	}
 
-	// 设置 Authorization header
-	req.Header.Set("Authorization", "Bearer "+accessToken)
+	// 设置 Authorization header（启用 DPoP 时使用 DPoP 认证方案）
+	setAccessTokenAuthorization(c, req, accessToken)
 
 	return req, nil
 }
 
 // doUserInfoRequest 发送用户信息请求并返回响应与响应体
 func doUserInfoRequest(c *Client, req *http.Request) (*http.Response, []byte, error) {
-	return httpx.Do(c.cfg.HTTPClient, req)
+	return doDPoPRequest(c, req)
 }
 
 // parseUserInfoResponse 解析用户信息响应
@@ -6,8 +6,6 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
-
-	"github.com/3086953492/goauthsdk/internal/httpx"
 )
 
 // GetUser 根据 sub 获取用户详情
@@ -76,15 +74,15 @@ func buildGetUserRequest(ctx context.Context, c *Client, accessToken string, sub
 		return nil, fmt.Errorf("create get user request: %w", err)
 	}
 
-	// 设置 Authorization header
-	req.Header.Set("Authorization", "Bearer "+accessToken)
+	// 设置 Authorization header（启用 DPoP 时使用 DPoP 认证方案）
+	setAccessTokenAuthorization(c, req, accessToken)
 
 	return req, nil
 }
 
 // doGetUserRequest 发送获取用户详情请求并返回响应与响应体
 func doGetUserRequest(c *Client, req *http.Request) (*http.Response, []byte, error) {
-	return httpx.Do(c.cfg.HTTPClient, req)
+	return doDPoPRequest(c, req)
 }
 
 // parseGetUserResponse 解析获取用户详情响应
@@ -0,0 +1,131 @@
+package goauthsdk
+
+import (
+	"strings"
+)
+
+// authChallenge 是 WWW-Authenticate 头部中的单个认证质询（RFC 9110 11.6.1）
+type authChallenge struct {
+	scheme string
+	params map[string]string
+}
+
+// parseWWWAuthenticate 解析 WWW-Authenticate 头部，返回其中的全部质询
+// 参数名统一转为小写；token68 形式的质询只保留 scheme；格式不合法的部分被忽略
+func parseWWWAuthenticate(header string) []authChallenge {
+	var challenges []authChallenge
+	s := header
+	for {
+		s = strings.TrimLeft(s, " \t,")
+		if s == "" {
+			return challenges
+		}
+
+		scheme := readToken(&s)
+		if scheme == "" {
+			return challenges
+		}
+		challenge := authChallenge{scheme: scheme, params: map[string]string{}}
+
+		// 读取 auth-param，直到遇到下一个质询的 scheme
+		for first := true; ; first = false {
+			rest := strings.TrimLeft(s, " \t")
+			separated := strings.HasPrefix(rest, ",")
+			rest = strings.TrimLeft(rest, " \t,")
+			name := readToken(&rest)
+			if name == "" {
+				s = rest
+				break
+			}
+
+			value := strings.TrimLeft(rest, " \t")
+			if !strings.HasPrefix(value, "=") || isToken68Padding(value[1:]) {
+				if first && !separated {
+					// token68（例如 Basic 的凭据），跳过
+					s = strings.TrimLeft(value, "=")
+				}
+				break
+			}
+
+			rest = strings.TrimLeft(value[1:], " \t")
+			var v string
+			if strings.HasPrefix(rest, `"`) {
+				v, rest = readQuotedString(rest)
+			} else {
+				v = readToken(&rest)
+			}
+			challenge.params[strings.ToLower(name)] = v
+			s = rest
+		}
+		challenges = append(challenges, challenge)
+	}
+}
+
+// isToken68Padding 判断 "=" 之后的内容是否表明该 "=" 属于 token68 的尾部填充而非 auth-param 的赋值
+func isToken68Padding(s string) bool {
+	s = strings.TrimLeft(s, " \t")
+	return s == "" || s[0] == '=' || s[0] == ','
+}
+
+// readToken 读取开头的 token（RFC 9110 5.6.2）并前移 s
+func readToken(s *string) string {
+	i := 0
+	for i < len(*s) && isTokenChar((*s)[i]) {
+		i++
+	}
+	token := (*s)[:i]
+	*s = (*s)[i:]
+	return token
+}
+
+// readQuotedString 读取开头的 quoted-string，返回去除转义后的值与剩余部分
+func readQuotedString(s string) (string, string) {
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if i+1 < len(s) {
+				i++
+				b.WriteByte(s[i])
+			}
+		case '"':
+			return b.String(), s[i+1:]
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String(), ""
+}
+
+// isTokenChar 判断字符是否属于 tchar（RFC 9110 5.6.2）
+func isTokenChar(c byte) bool {
+	switch {
+	case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		return true
+	}
+	return strings.IndexByte("!#$%&'*+-.^_`|~", c) >= 0
+}
+
+// formatWWWAuthenticate 构建 WWW-Authenticate 质询，参数按 names 的顺序输出，值为空的参数被省略
+func formatWWWAuthenticate(scheme string, names []string, params map[string]string) string {
+	var b strings.Builder
+	b.WriteString(scheme)
+	first := true
+	for _, name := range names {
+		value, ok := params[name]
+		if !ok || value == "" {
+			continue
+		}
+		if first {
+			b.WriteByte(' ')
+			first = false
+		} else {
+			b.WriteString(", ")
+		}
+		b.WriteString(name)
+		b.WriteString(`="`)
+		b.WriteString(strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value))
+		b.WriteByte('"')
+	}
+	return b.String()
+}